@@ -0,0 +1,134 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// SelectorLength is the length, in bytes, of a function selector.
+const SelectorLength = 4
+
+// Method is a single callable entry point of a contract, as described
+// by its ABI.
+type Method struct {
+	Name    string    `json:"name"`
+	Inputs  Arguments `json:"inputs"`
+	Outputs Arguments `json:"outputs"`
+	// Constant marks a read-only method, i.e. one that can be served
+	// by a Call rather than requiring a submitted tx.
+	Constant bool `json:"constant"`
+}
+
+// Sig returns the method's canonical signature, e.g.
+// "transfer(address,uint256)", which is the preimage hashed to
+// produce its selector.
+func (m Method) Sig() string {
+	types := make([]string, len(m.Inputs))
+	for i, arg := range m.Inputs {
+		types[i] = arg.Type.String()
+	}
+	return fmt.Sprintf("%s(%s)", m.Name, strings.Join(types, ","))
+}
+
+// ID returns the method's canonical 4-byte selector:
+// keccak256(Sig())[:4].
+func (m Method) ID() [SelectorLength]byte {
+	return Selector(m.Sig())
+}
+
+// Selector computes the canonical 4-byte function selector for a
+// signature string of the form "name(type1,type2,...)".
+func Selector(sig string) [SelectorLength]byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(sig))
+	sum := hash.Sum(nil)
+	var id [SelectorLength]byte
+	copy(id[:], sum[:SelectorLength])
+	return id
+}
+
+// ABI is the parsed, JSON-driven description of a contract's callable
+// entry points. It is what JSON parses from the ABI JSON a contract
+// is registered with at CreateContract time and that GetContractABI
+// returns.
+type ABI struct {
+	Methods map[string]Method `json:"methods"`
+}
+
+// rawMethod is the JSON shape of a single ABI entry.
+type rawMethod struct {
+	Name            string               `json:"name"`
+	Inputs          []ArgumentMarshaling `json:"inputs"`
+	Outputs         []ArgumentMarshaling `json:"outputs"`
+	Constant        bool                 `json:"constant"`
+	StateMutability string               `json:"stateMutability"`
+}
+
+// JSON parses a contract ABI from its JSON representation: an array
+// of method descriptors, each with a name and typed inputs/outputs.
+func JSON(r io.Reader) (ABI, error) {
+	var raw []rawMethod
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return ABI{}, fmt.Errorf("couldn't decode ABI JSON: %v", err)
+	}
+
+	methods := make(map[string]Method, len(raw))
+	for _, m := range raw {
+		inputs, err := toArguments(m.Inputs)
+		if err != nil {
+			return ABI{}, fmt.Errorf("method %q: invalid inputs: %v", m.Name, err)
+		}
+		outputs, err := toArguments(m.Outputs)
+		if err != nil {
+			return ABI{}, fmt.Errorf("method %q: invalid outputs: %v", m.Name, err)
+		}
+		constant := m.Constant || m.StateMutability == "view" || m.StateMutability == "pure"
+		method := Method{Name: m.Name, Inputs: inputs, Outputs: outputs, Constant: constant}
+		if _, exists := methods[method.Name]; exists {
+			return ABI{}, fmt.Errorf("duplicate method name %q", method.Name)
+		}
+		methods[method.Name] = method
+	}
+	return ABI{Methods: methods}, nil
+}
+
+func toArguments(raw []ArgumentMarshaling) (Arguments, error) {
+	args := make(Arguments, len(raw))
+	for i, a := range raw {
+		typ, err := NewType(a.Type, a.Components...)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d (%s): %v", i, a.Name, err)
+		}
+		args[i] = Argument{Name: a.Name, Type: typ}
+	}
+	return args, nil
+}
+
+// MethodByID looks up a method by its 4-byte selector. It returns an
+// error if no method, or more than one, matches - the latter can only
+// happen on a hash collision across an ABI's own methods.
+func (a ABI) MethodByID(selector []byte) (Method, error) {
+	if len(selector) != SelectorLength {
+		return Method{}, fmt.Errorf("selector must be %d bytes, got %d", SelectorLength, len(selector))
+	}
+	var found []Method
+	for _, m := range a.Methods {
+		id := m.ID()
+		if bytes.Equal(id[:], selector) {
+			found = append(found, m)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Method{}, fmt.Errorf("no method with selector 0x%x", selector)
+	case 1:
+		return found[0], nil
+	default:
+		return Method{}, fmt.Errorf("%d methods share selector 0x%x", len(found), selector)
+	}
+}
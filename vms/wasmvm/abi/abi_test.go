@@ -0,0 +1,120 @@
+package abi
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArgumentsPackUnpackRoundTrip(t *testing.T) {
+	bigUint256, _ := new(big.Int).SetString("12345678901234567890", 10)
+	tests := []struct {
+		name  string
+		typ   string
+		value interface{}
+		want  interface{}
+	}{
+		{"uint256", "uint256", "12345678901234567890", bigUint256},
+		{"int64 negative", "int64", "-42", big.NewInt(-42)},
+		{"bool true", "bool", true, true},
+		{"string", "string", "hello wasmvm", "hello wasmvm"},
+		{"bytes", "bytes", []byte{1, 2, 3, 4}, []byte{1, 2, 3, 4}},
+		{"uint64 slice", "uint64[]", []interface{}{"1", "2", "3"}, []interface{}{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+		{"fixed array", "uint32[2]", []interface{}{"7", "8"}, []interface{}{big.NewInt(7), big.NewInt(8)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, err := NewType(tt.typ)
+			if err != nil {
+				t.Fatalf("NewType(%q): %v", tt.typ, err)
+			}
+			args := Arguments{{Name: "x", Type: typ}}
+
+			packed, err := args.Pack(tt.value)
+			if err != nil {
+				t.Fatalf("Pack: %v", err)
+			}
+
+			unpacked, err := args.Unpack(packed)
+			if err != nil {
+				t.Fatalf("Unpack: %v", err)
+			}
+			if len(unpacked) != 1 {
+				t.Fatalf("got %d unpacked values, want 1", len(unpacked))
+			}
+			if !reflect.DeepEqual(unpacked[0], tt.want) {
+				t.Fatalf("got %#v, want %#v", unpacked[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestPackUintRejectsOutOfRangeValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   string
+		value string
+	}{
+		{"uint8 over max", "uint8", "1000"},
+		{"uint8 just over max", "uint8", "256"},
+		{"uint256 over max", "uint256", "1" + strings.Repeat("0", 80)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, err := NewType(tt.typ)
+			if err != nil {
+				t.Fatalf("NewType(%q): %v", tt.typ, err)
+			}
+			if _, err := (Arguments{{Name: "x", Type: typ}}).Pack(tt.value); err == nil {
+				t.Fatalf("Pack(%q) as %s: expected overflow error, got none", tt.value, tt.typ)
+			}
+		})
+	}
+}
+
+func TestPackUintAcceptsBoundaryValues(t *testing.T) {
+	typ, err := NewType("uint8")
+	if err != nil {
+		t.Fatalf("NewType: %v", err)
+	}
+	args := Arguments{{Name: "x", Type: typ}}
+	if _, err := args.Pack("255"); err != nil {
+		t.Fatalf("Pack(255) as uint8 should succeed: %v", err)
+	}
+}
+
+func TestSelectorIsDeterministic(t *testing.T) {
+	sig := "transfer(address,uint256)"
+	a := Selector(sig)
+	b := Selector(sig)
+	if a != b {
+		t.Fatalf("Selector(%q) not deterministic: %x != %x", sig, a, b)
+	}
+	if Selector("other(uint256)") == a {
+		t.Fatal("different signatures produced the same selector")
+	}
+}
+
+func TestMethodByID(t *testing.T) {
+	m := Method{Name: "transfer", Inputs: Arguments{
+		{Name: "to", Type: Type{Kind: AddressTy, stringKind: "address"}},
+		{Name: "amount", Type: Type{Kind: UintTy, Size: 256, stringKind: "uint256"}},
+	}}
+	contractABI := ABI{Methods: map[string]Method{"transfer": m}}
+
+	id := m.ID()
+	got, err := contractABI.MethodByID(id[:])
+	if err != nil {
+		t.Fatalf("MethodByID: %v", err)
+	}
+	if got.Name != "transfer" {
+		t.Fatalf("got method %q, want %q", got.Name, "transfer")
+	}
+
+	if _, err := contractABI.MethodByID([]byte{0, 0, 0, 0}); err == nil {
+		t.Fatal("expected error for unknown selector")
+	}
+}
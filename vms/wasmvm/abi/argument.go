@@ -0,0 +1,66 @@
+package abi
+
+import "fmt"
+
+// Argument is a single named, typed member of an Arguments list, e.g.
+// one parameter of a contract function or one field of a tuple.
+type Argument struct {
+	Name    string `json:"name"`
+	Type    Type   `json:"type"`
+	Indexed bool   `json:"indexed"` // used by event topics; unused for call args
+}
+
+// Arguments is an ordered list of Argument, analogous to a function's
+// parameter list or a tuple's field list. It packs a slice of Go
+// values into the blob layout described by its Types, and unpacks
+// that blob back into Go values.
+type Arguments []Argument
+
+// Pack packs values, one per Argument in order, into a single blob:
+// the concatenation of each value's encoding, with no overall length
+// prefix. The blob is suitable for passing across the WASM call
+// boundary via a pointer argument.
+func (args Arguments) Pack(values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("argument count mismatch: got %d values for %d arguments", len(values), len(args))
+	}
+
+	var packed []byte
+	for i, arg := range args {
+		enc, err := packElement(arg.Type, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't pack argument %d (%s %s): %v", i, arg.Type, arg.Name, err)
+		}
+		packed = append(packed, enc...)
+	}
+	return packed, nil
+}
+
+// Unpack decodes a packed blob back into one Go value per Argument, in
+// order.
+func (args Arguments) Unpack(data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(args))
+	offset := 0
+	for i, arg := range args {
+		val, consumed, err := unpackElement(arg.Type, data, offset)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unpack argument %d (%s %s): %v", i, arg.Type, arg.Name, err)
+		}
+		values[i] = val
+		offset += consumed
+	}
+	return values, nil
+}
+
+// NonIndexed returns the subset of args that are not event-indexed
+// topics, preserving order. Call arguments are always non-indexed;
+// this exists so log topic/data splitting can reuse Arguments.
+func (args Arguments) NonIndexed() Arguments {
+	var out Arguments
+	for _, arg := range args {
+		if !arg.Indexed {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
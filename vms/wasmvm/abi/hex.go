@@ -0,0 +1,15 @@
+package abi
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// parseHexOrRaw decodes s as hex, tolerating an optional "0x" prefix.
+// It exists because JSON args for bytesN/address/bytes fields arrive
+// as strings, and callers shouldn't have to remember whether to
+// include the prefix.
+func parseHexOrRaw(s string) ([]byte, error) {
+	s = strings.TrimPrefix(s, "0x")
+	return hex.DecodeString(s)
+}
@@ -0,0 +1,87 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// wordSize is the width, in bytes, of a packed uint/int/address/
+// fixed-bytes word. It mirrors the 32-byte EVM word used by
+// Ethereum's ABI so tooling built against that ecosystem (e.g. the
+// keccak256 selector) stays familiar to contract authors.
+const wordSize = 32
+
+// toBigInt converts an arg value (float64 from JSON, string for
+// values too large for float64, or an already-native integer type)
+// into a *big.Int.
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, nil
+	case string:
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("value %q is not a base-10 integer", v)
+		}
+		return n, nil
+	case float64:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	default:
+		return nil, fmt.Errorf("value %v (%T) is not convertible to an integer", value, value)
+	}
+}
+
+// packUint packs n as an unsigned big-endian word, left-padded with
+// zero bytes to wordSize. It returns an error if n doesn't fit in
+// bits, mirroring the overflow check packInt does for signed values.
+func packUint(n *big.Int, bits int) ([]byte, error) {
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("value %s is negative, want unsigned", n)
+	}
+	if n.BitLen() > bits {
+		return nil, fmt.Errorf("value %s overflows uint%d", n, bits)
+	}
+	return padLeft(n.Bytes()), nil
+}
+
+// packInt packs n as a signed big-endian word in two's complement
+// form, left-padded with the sign byte to wordSize.
+func packInt(n *big.Int, bits int) ([]byte, error) {
+	max := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	min := new(big.Int).Neg(max)
+	if n.Cmp(min) < 0 || n.Cmp(new(big.Int).Sub(max, big.NewInt(1))) > 0 {
+		return nil, fmt.Errorf("value %s overflows int%d", n, bits)
+	}
+	if n.Sign() >= 0 {
+		return padLeft(n.Bytes()), nil
+	}
+	twosComplement := new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), uint(wordSize*8)))
+	word := padLeft(twosComplement.Bytes())
+	return word, nil
+}
+
+func padLeft(b []byte) []byte {
+	if len(b) >= wordSize {
+		return b[len(b)-wordSize:]
+	}
+	word := make([]byte, wordSize)
+	copy(word[wordSize-len(b):], b)
+	return word
+}
+
+func unpackUint(word []byte) *big.Int {
+	return new(big.Int).SetBytes(word)
+}
+
+func unpackInt(word []byte) *big.Int {
+	n := new(big.Int).SetBytes(word)
+	// If the top bit is set, this is a negative two's-complement value.
+	if word[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(wordSize*8)))
+	}
+	return n
+}
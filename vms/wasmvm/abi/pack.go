@@ -0,0 +1,170 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// packElement encodes a single Go value according to typ. Static
+// types (uint*, int*, bool, address, bytesN) are packed as a single
+// wordSize-byte word. Dynamic types (bytes, string, T[]) are packed
+// as a 4-byte big-endian element/byte count followed by the payload,
+// so Unpack can walk a blob without an out-of-band schema beyond the
+// Arguments list itself. Fixed-length arrays (T[k]) and tuples are
+// packed as the plain concatenation of their elements or fields, with
+// no count prefix.
+func packElement(typ Type, value interface{}) ([]byte, error) {
+	switch typ.Kind {
+	case UintTy:
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		return packUint(n, typ.Size)
+	case IntTy:
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		return packInt(n, typ.Size)
+	case BoolTy:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("value %v is not a bool", value)
+		}
+		word := make([]byte, wordSize)
+		if b {
+			word[wordSize-1] = 1
+		}
+		return word, nil
+	case AddressTy:
+		raw, err := toFixedBytes(value, AddressLength)
+		if err != nil {
+			return nil, err
+		}
+		return padLeft(raw), nil
+	case FixedBytesTy:
+		raw, err := toFixedBytes(value, typ.Size)
+		if err != nil {
+			return nil, err
+		}
+		word := make([]byte, wordSize)
+		copy(word, raw) // right-padded, like Ethereum's bytesN encoding
+		return word, nil
+	case BytesTy:
+		raw, err := toByteSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		return packDynamic(raw), nil
+	case StringTy:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("value %v is not a string", value)
+		}
+		return packDynamic([]byte(s)), nil
+	case SliceTy:
+		return packSlice(*typ.Elem, value)
+	case ArrayTy:
+		elems, ok := toSlice(value)
+		if !ok {
+			return nil, fmt.Errorf("value %v is not an array", value)
+		}
+		if len(elems) != typ.SliceSize {
+			return nil, fmt.Errorf("array length mismatch: got %d, want %d", len(elems), typ.SliceSize)
+		}
+		return packElements(*typ.Elem, elems)
+	case TupleTy:
+		return packTuple(typ, value)
+	default:
+		return nil, fmt.Errorf("cannot pack unknown type kind %d", typ.Kind)
+	}
+}
+
+// packDynamic wraps raw bytes with a 4-byte big-endian length prefix.
+func packDynamic(raw []byte) []byte {
+	out := make([]byte, 4, 4+len(raw))
+	binary.BigEndian.PutUint32(out, uint32(len(raw)))
+	return append(out, raw...)
+}
+
+func packSlice(elemType Type, value interface{}) ([]byte, error) {
+	elems, ok := toSlice(value)
+	if !ok {
+		return nil, fmt.Errorf("value %v is not a slice", value)
+	}
+	body, err := packElements(elemType, elems)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 4, 4+len(body))
+	binary.BigEndian.PutUint32(out, uint32(len(elems)))
+	return append(out, body...), nil
+}
+
+func packElements(elemType Type, elems []interface{}) ([]byte, error) {
+	var out []byte
+	for i, elem := range elems {
+		enc, err := packElement(elemType, elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %v", i, err)
+		}
+		out = append(out, enc...)
+	}
+	return out, nil
+}
+
+func packTuple(typ Type, value interface{}) ([]byte, error) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("value %v is not a tuple (object)", value)
+	}
+	var out []byte
+	for _, comp := range typ.Components {
+		compType, err := NewType(comp.Type, comp.Components...)
+		if err != nil {
+			return nil, fmt.Errorf("component %q: %v", comp.Name, err)
+		}
+		fieldVal, ok := fields[comp.Name]
+		if !ok {
+			return nil, fmt.Errorf("tuple missing field %q", comp.Name)
+		}
+		enc, err := packElement(compType, fieldVal)
+		if err != nil {
+			return nil, fmt.Errorf("component %q: %v", comp.Name, err)
+		}
+		out = append(out, enc...)
+	}
+	return out, nil
+}
+
+func toSlice(value interface{}) ([]interface{}, bool) {
+	s, ok := value.([]interface{})
+	return s, ok
+}
+
+// toFixedBytes accepts either a raw byte slice (already the right
+// length or shorter) or a hex string ("0x..." or bare) and returns
+// its bytes unpadded, which may be fewer than n. It returns an error
+// if there are more than n.
+func toFixedBytes(value interface{}, n int) ([]byte, error) {
+	raw, err := toByteSlice(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > n {
+		return nil, fmt.Errorf("value is %d bytes, want at most %d", len(raw), n)
+	}
+	return raw, nil
+}
+
+func toByteSlice(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return parseHexOrRaw(v)
+	default:
+		return nil, fmt.Errorf("value %v (%T) is not bytes-like", value, value)
+	}
+}
@@ -0,0 +1,152 @@
+// Package abi implements an Ethereum-style ABI (Application Binary
+// Interface) subsystem for wasmvm contracts: a JSON-driven type
+// descriptor, argument packing/unpacking into WASM linear-memory
+// layout, and canonical function selectors.
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// T describes the kind of an ABI Type.
+type T int
+
+// Kinds of ABI types.
+const (
+	UintTy T = iota
+	IntTy
+	BoolTy
+	StringTy
+	BytesTy      // dynamic-length byte slice ("bytes")
+	FixedBytesTy // fixed-length byte array ("bytesN")
+	AddressTy
+	SliceTy // dynamic-length array ("T[]")
+	ArrayTy // fixed-length array ("T[k]")
+	TupleTy // struct ("tuple")
+)
+
+// AddressLength is the length, in bytes, of an "address" value.
+const AddressLength = 20
+
+var typeRegex = regexp.MustCompile(`^([a-zA-Z]+)(\d*)(\[(\d*)\])?$`)
+
+// Type is the descriptor for a single ABI type. It is produced by
+// NewType from a type string such as "uint256", "bytes32", "address"
+// or "uint64[]", and is the unit that Arguments.Pack/Unpack operate on.
+type Type struct {
+	// Elem is the element type, set when T is SliceTy or ArrayTy.
+	Elem *Type
+	// Components are the tuple's fields, set when T is TupleTy.
+	Components []ArgumentMarshaling
+
+	Kind T
+	// Size is the bit size for UintTy/IntTy, or the byte length for
+	// FixedBytesTy. It is 0 for types where it doesn't apply.
+	Size int
+	// SliceSize is the fixed length of an ArrayTy. It is 0 for SliceTy.
+	SliceSize int
+
+	// stringKind is the type's canonical string form, e.g. "uint256[]".
+	stringKind string
+}
+
+// ArgumentMarshaling is the JSON representation of a tuple component,
+// mirroring the shape accepted for "components" in a ABI JSON document.
+type ArgumentMarshaling struct {
+	Name       string               `json:"name"`
+	Type       string               `json:"type"`
+	Components []ArgumentMarshaling `json:"components,omitempty"`
+}
+
+// NewType parses a type string, e.g. "uint256", "bytes32", "address",
+// "uint64[]" or "tuple", into a Type descriptor. components is only
+// consulted when stringKind is "tuple" or a tuple array.
+func NewType(stringKind string, components ...ArgumentMarshaling) (Type, error) {
+	stringKind = strings.TrimSpace(stringKind)
+
+	// Arrays and slices: recurse on the element type.
+	if strings.HasSuffix(stringKind, "]") {
+		start := strings.LastIndex(stringKind, "[")
+		if start == -1 {
+			return Type{}, fmt.Errorf("invalid array type %q", stringKind)
+		}
+		elem, err := NewType(stringKind[:start], components...)
+		if err != nil {
+			return Type{}, err
+		}
+		inner := stringKind[start+1 : len(stringKind)-1]
+		if inner == "" {
+			return Type{Kind: SliceTy, Elem: &elem, stringKind: stringKind}, nil
+		}
+		size, err := strconv.Atoi(inner)
+		if err != nil {
+			return Type{}, fmt.Errorf("invalid array length %q: %v", inner, err)
+		}
+		return Type{Kind: ArrayTy, Elem: &elem, SliceSize: size, stringKind: stringKind}, nil
+	}
+
+	if stringKind == "tuple" {
+		return Type{Kind: TupleTy, Components: components, stringKind: stringKind}, nil
+	}
+
+	matches := typeRegex.FindStringSubmatch(stringKind)
+	if len(matches) == 0 {
+		return Type{}, fmt.Errorf("invalid type %q", stringKind)
+	}
+	base, sizeStr := matches[1], matches[2]
+
+	switch base {
+	case "uint":
+		size := 256
+		if sizeStr != "" {
+			var err error
+			if size, err = strconv.Atoi(sizeStr); err != nil {
+				return Type{}, fmt.Errorf("invalid uint size %q: %v", sizeStr, err)
+			}
+		}
+		if size%8 != 0 || size < 8 || size > 256 {
+			return Type{}, fmt.Errorf("invalid uint size %d: must be a multiple of 8 between 8 and 256", size)
+		}
+		return Type{Kind: UintTy, Size: size, stringKind: stringKind}, nil
+	case "int":
+		size := 256
+		if sizeStr != "" {
+			var err error
+			if size, err = strconv.Atoi(sizeStr); err != nil {
+				return Type{}, fmt.Errorf("invalid int size %q: %v", sizeStr, err)
+			}
+		}
+		if size%8 != 0 || size < 8 || size > 256 {
+			return Type{}, fmt.Errorf("invalid int size %d: must be a multiple of 8 between 8 and 256", size)
+		}
+		return Type{Kind: IntTy, Size: size, stringKind: stringKind}, nil
+	case "bool":
+		return Type{Kind: BoolTy, stringKind: stringKind}, nil
+	case "string":
+		return Type{Kind: StringTy, stringKind: stringKind}, nil
+	case "address":
+		return Type{Kind: AddressTy, stringKind: stringKind}, nil
+	case "bytes":
+		if sizeStr == "" {
+			return Type{Kind: BytesTy, stringKind: stringKind}, nil
+		}
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			return Type{}, fmt.Errorf("invalid bytes size %q: %v", sizeStr, err)
+		}
+		if size < 1 || size > 32 {
+			return Type{}, fmt.Errorf("invalid bytesN size %d: must be between 1 and 32", size)
+		}
+		return Type{Kind: FixedBytesTy, Size: size, stringKind: stringKind}, nil
+	default:
+		return Type{}, errors.New("unsupported arg type: " + stringKind)
+	}
+}
+
+// String returns the type's canonical string form, as used in a
+// function signature, e.g. "uint256[]".
+func (t Type) String() string { return t.stringKind }
@@ -0,0 +1,137 @@
+package abi
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// unpackElement decodes a single value of typ from data starting at
+// offset, returning the decoded value and the number of bytes
+// consumed. It is the inverse of packElement.
+func unpackElement(typ Type, data []byte, offset int) (interface{}, int, error) {
+	switch typ.Kind {
+	case UintTy:
+		word, err := readWord(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		return unpackUint(word), wordSize, nil
+	case IntTy:
+		word, err := readWord(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		return unpackInt(word), wordSize, nil
+	case BoolTy:
+		word, err := readWord(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		return word[wordSize-1] != 0, wordSize, nil
+	case AddressTy:
+		word, err := readWord(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		return "0x" + hex.EncodeToString(word[wordSize-AddressLength:]), wordSize, nil
+	case FixedBytesTy:
+		word, err := readWord(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		raw := make([]byte, typ.Size)
+		copy(raw, word[:typ.Size])
+		return raw, wordSize, nil
+	case BytesTy:
+		raw, consumed, err := readDynamic(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		out := make([]byte, len(raw))
+		copy(out, raw)
+		return out, consumed, nil
+	case StringTy:
+		raw, consumed, err := readDynamic(data, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+		return string(raw), consumed, nil
+	case SliceTy:
+		return unpackSlice(*typ.Elem, data, offset)
+	case ArrayTy:
+		values := make([]interface{}, typ.SliceSize)
+		consumed := 0
+		for i := 0; i < typ.SliceSize; i++ {
+			val, n, err := unpackElement(*typ.Elem, data, offset+consumed)
+			if err != nil {
+				return nil, 0, fmt.Errorf("element %d: %v", i, err)
+			}
+			values[i] = val
+			consumed += n
+		}
+		return values, consumed, nil
+	case TupleTy:
+		return unpackTuple(typ, data, offset)
+	default:
+		return nil, 0, fmt.Errorf("cannot unpack unknown type kind %d", typ.Kind)
+	}
+}
+
+func readWord(data []byte, offset int) ([]byte, error) {
+	if offset+wordSize > len(data) {
+		return nil, fmt.Errorf("truncated blob: need %d bytes at offset %d, have %d", wordSize, offset, len(data))
+	}
+	return data[offset : offset+wordSize], nil
+}
+
+// readDynamic reads a 4-byte big-endian length prefix followed by
+// that many bytes, returning the payload and the total bytes consumed
+// (prefix included).
+func readDynamic(data []byte, offset int) ([]byte, int, error) {
+	if offset+4 > len(data) {
+		return nil, 0, fmt.Errorf("truncated blob: need 4-byte length at offset %d, have %d", offset, len(data))
+	}
+	length := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+	start := offset + 4
+	if start+length > len(data) {
+		return nil, 0, fmt.Errorf("truncated blob: need %d bytes at offset %d, have %d", length, start, len(data))
+	}
+	return data[start : start+length], 4 + length, nil
+}
+
+func unpackSlice(elemType Type, data []byte, offset int) ([]interface{}, int, error) {
+	if offset+4 > len(data) {
+		return nil, 0, fmt.Errorf("truncated blob: need 4-byte count at offset %d, have %d", offset, len(data))
+	}
+	count := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+	consumed := 4
+	values := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		val, n, err := unpackElement(elemType, data, offset+consumed)
+		if err != nil {
+			return nil, 0, fmt.Errorf("element %d: %v", i, err)
+		}
+		values[i] = val
+		consumed += n
+	}
+	return values, consumed, nil
+}
+
+func unpackTuple(typ Type, data []byte, offset int) (map[string]interface{}, int, error) {
+	fields := make(map[string]interface{}, len(typ.Components))
+	consumed := 0
+	for _, comp := range typ.Components {
+		compType, err := NewType(comp.Type, comp.Components...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("component %q: %v", comp.Name, err)
+		}
+		val, n, err := unpackElement(compType, data, offset+consumed)
+		if err != nil {
+			return nil, 0, fmt.Errorf("component %q: %v", comp.Name, err)
+		}
+		fields[comp.Name] = val
+		consumed += n
+	}
+	return fields, consumed, nil
+}
@@ -0,0 +1,40 @@
+// Package bind generates strongly-typed Go clients for wasmvm
+// contracts from a contract's ABI, following the template-driven
+// approach of Ethereum's accounts/abi/bind: one struct per contract,
+// one method per exported function, argument packing and result
+// unpacking handled for the caller.
+package bind
+
+import (
+	"context"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+)
+
+// ContractCaller makes read-only calls against a contract's current
+// state. A generated binding's read-only methods are routed through
+// this interface so they can run against either a live node or an
+// in-process simulator (see bind/backends.SimulatedBackend).
+type ContractCaller interface {
+	CallContract(ctx context.Context, contractID ids.ID, function string, packedArgs []byte) ([]byte, error)
+}
+
+// ContractTransactor submits a state-changing invocation and returns
+// the ID of the tx that carries it.
+type ContractTransactor interface {
+	Invoke(ctx context.Context, contractID ids.ID, function string, packedArgs []byte, senderKey *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error)
+	// NextNonce returns the backend's authoritative next-expected
+	// nonce for senderKey, so a Session whose local NonceManager has
+	// drifted (a dropped tx, a concurrent sender) can resync instead
+	// of guessing.
+	NextNonce(ctx context.Context, senderKey *crypto.PrivateKeySECP256K1R) (uint64, error)
+}
+
+// ContractBackend is the minimal surface a generated binding needs:
+// read-only calls for its ContractCaller half, and tx submission for
+// its ContractTransactor half.
+type ContractBackend interface {
+	ContractCaller
+	ContractTransactor
+}
@@ -0,0 +1,24 @@
+package backends
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// hashTx deterministically derives a synthetic tx ID for a simulated
+// invocation from the contract, function and nonce it carried. It
+// isn't used for anything security-sensitive - just so repeated calls
+// in a test get distinct, stable IDs to assert against.
+func hashTx(contractID ids.ID, function string, nonce uint64) [32]byte {
+	h := sha256.New()
+	h.Write(contractID.Bytes())
+	h.Write([]byte(function))
+	var nonceBytes [8]byte
+	binary.BigEndian.PutUint64(nonceBytes[:], nonce)
+	h.Write(nonceBytes[:])
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
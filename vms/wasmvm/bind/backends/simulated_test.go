@@ -0,0 +1,64 @@
+package backends
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+)
+
+type fakeInterpreter struct{}
+
+func (fakeInterpreter) Invoke(ids.ID, string, []byte) ([]byte, error) { return nil, nil }
+
+func newTestKey(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	t.Helper()
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	return key.(*crypto.PrivateKeySECP256K1R)
+}
+
+func TestSimulatedBackendFirstNonceIsOne(t *testing.T) {
+	b := NewSimulatedBackend(fakeInterpreter{})
+	contractID := ids.NewID([32]byte{1})
+	b.Deploy(contractID)
+	key := newTestKey(t)
+
+	next, err := b.NextNonce(context.Background(), key)
+	if err != nil {
+		t.Fatalf("NextNonce: %v", err)
+	}
+	if next != 1 {
+		t.Fatalf("NextNonce for unseen sender = %d, want 1", next)
+	}
+
+	if _, err := b.Invoke(context.Background(), contractID, "f", nil, key, 0); err == nil {
+		t.Fatal("Invoke with nonce 0 should be rejected as too low")
+	}
+	if _, err := b.Invoke(context.Background(), contractID, "f", nil, key, 1); err != nil {
+		t.Fatalf("Invoke with the documented first nonce (1) failed: %v", err)
+	}
+}
+
+func TestSimulatedBackendNextNonceAdvancesAfterInvoke(t *testing.T) {
+	b := NewSimulatedBackend(fakeInterpreter{})
+	contractID := ids.NewID([32]byte{2})
+	b.Deploy(contractID)
+	key := newTestKey(t)
+
+	if _, err := b.Invoke(context.Background(), contractID, "f", nil, key, 1); err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+
+	next, err := b.NextNonce(context.Background(), key)
+	if err != nil {
+		t.Fatalf("NextNonce: %v", err)
+	}
+	if next != 2 {
+		t.Fatalf("NextNonce after one Invoke = %d, want 2", next)
+	}
+}
@@ -0,0 +1,55 @@
+// Command gecko-wasm-bind generates a strongly-typed Go client for a
+// wasmvm contract from its ABI JSON, the way abigen does for Ethereum
+// contracts.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
+	"github.com/ava-labs/gecko/vms/wasmvm/bind"
+)
+
+func main() {
+	abiPath := flag.String("abi", "", "path to the contract's ABI JSON file")
+	name := flag.String("type", "", "name of the generated Go type, e.g. Token")
+	pkg := flag.String("pkg", "main", "name of the package to generate")
+	out := flag.String("out", "", "output file (defaults to stdout)")
+	flag.Parse()
+
+	if err := run(*abiPath, *name, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "gecko-wasm-bind:", err)
+		os.Exit(1)
+	}
+}
+
+func run(abiPath, name, pkg, out string) error {
+	if abiPath == "" || name == "" {
+		return fmt.Errorf("both -abi and -type are required")
+	}
+
+	abiJSON, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		return fmt.Errorf("couldn't read ABI file: %v", err)
+	}
+
+	parsedABI, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("couldn't parse ABI: %v", err)
+	}
+
+	src, err := bind.Bind(name, parsedABI, string(abiJSON), pkg)
+	if err != nil {
+		return fmt.Errorf("couldn't generate binding: %v", err)
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
@@ -0,0 +1,77 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
+)
+
+// BoundContract is the common plumbing a generated contract struct
+// embeds: it knows how to pack a method call's arguments, dispatch it
+// through a ContractBackend, and unpack the result. Generated code
+// should not need to touch abi directly.
+type BoundContract struct {
+	ContractID ids.ID
+	ABI        abi.ABI
+	Backend    ContractBackend
+}
+
+// NewBoundContract returns a BoundContract ready for a generated
+// binding's methods to call Call/Transact on.
+func NewBoundContract(contractID ids.ID, contractABI abi.ABI, backend ContractBackend) *BoundContract {
+	return &BoundContract{ContractID: contractID, ABI: contractABI, Backend: backend}
+}
+
+// Call invokes a read-only method and unpacks its return values.
+func (c *BoundContract) Call(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	m, ok := c.ABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("contract has no method %q", method)
+	}
+	packed, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't pack args for %q: %v", method, err)
+	}
+	raw, err := c.Backend.CallContract(ctx, c.ContractID, method, packed)
+	if err != nil {
+		return nil, fmt.Errorf("call to %q failed: %v", method, err)
+	}
+	return m.Outputs.Unpack(raw)
+}
+
+// Transact submits a state-changing invocation via session, retrying
+// once if the first attempt is rejected for using a stale nonce. The
+// retry resyncs session's NonceManager against the backend's
+// authoritative next nonce first, rather than just incrementing the
+// local counter again - that would only repair a fresh session's
+// first call, not a real desync from a dropped tx or a concurrent
+// sender sharing the same session.
+func (c *BoundContract) Transact(ctx context.Context, session *Session, method string, args ...interface{}) (ids.ID, error) {
+	m, ok := c.ABI.Methods[method]
+	if !ok {
+		return ids.ID{}, fmt.Errorf("contract has no method %q", method)
+	}
+	packed, err := m.Inputs.Pack(args...)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't pack args for %q: %v", method, err)
+	}
+
+	nonce := session.Nonces.Take()
+	txID, err := c.Backend.Invoke(ctx, c.ContractID, method, packed, session.Key, nonce)
+	if err != nil && strings.Contains(err.Error(), errNonceTooLow) {
+		next, nonceErr := c.Backend.NextNonce(ctx, session.Key)
+		if nonceErr != nil {
+			return ids.ID{}, fmt.Errorf("invoke of %q failed: %v", method, err)
+		}
+		session.Nonces.Reset(next)
+		nonce = session.Nonces.Take()
+		txID, err = c.Backend.Invoke(ctx, c.ContractID, method, packed, session.Key, nonce)
+	}
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("invoke of %q failed: %v", method, err)
+	}
+	return txID, nil
+}
@@ -0,0 +1,88 @@
+package bind
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
+)
+
+// fakeBackend is a ContractBackend whose authoritative expected nonce
+// can be set independently of what a Session's NonceManager believes,
+// so Transact's resync-on-reject path can be exercised without a real
+// interpreter.
+type fakeBackend struct {
+	wantNonce  uint64
+	gotNonces  []uint64
+	nextNonces []uint64 // invocations of NextNonce, in order
+}
+
+func (b *fakeBackend) CallContract(context.Context, ids.ID, string, []byte) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (b *fakeBackend) Invoke(_ context.Context, _ ids.ID, _ string, _ []byte, _ *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error) {
+	b.gotNonces = append(b.gotNonces, nonce)
+	if nonce != b.wantNonce {
+		return ids.ID{}, errors.New(errNonceTooLow)
+	}
+	b.wantNonce++
+	return ids.NewID([32]byte{byte(nonce)}), nil
+}
+
+func (b *fakeBackend) NextNonce(context.Context, *crypto.PrivateKeySECP256K1R) (uint64, error) {
+	b.nextNonces = append(b.nextNonces, b.wantNonce)
+	return b.wantNonce, nil
+}
+
+func testKey(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	t.Helper()
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	return key.(*crypto.PrivateKeySECP256K1R)
+}
+
+func testTransferABI() abi.ABI {
+	return abi.ABI{Methods: map[string]abi.Method{"transfer": {Name: "transfer"}}}
+}
+
+func TestTransactSucceedsWithoutRetryWhenNonceIsCorrect(t *testing.T) {
+	backend := &fakeBackend{wantNonce: 1}
+	c := NewBoundContract(ids.ID{}, testTransferABI(), backend)
+	session := NewSession(testKey(t), 1)
+
+	if _, err := c.Transact(context.Background(), session, "transfer"); err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+	if len(backend.gotNonces) != 1 {
+		t.Fatalf("Invoke called %d times, want 1 (no retry needed)", len(backend.gotNonces))
+	}
+}
+
+func TestTransactResyncsNonceOnDesync(t *testing.T) {
+	// Session believes the next nonce is 1, but the backend - because
+	// of an earlier dropped tx the session never saw - actually
+	// expects 5. A blind increment-and-retry would try nonce 2 next
+	// and fail again; Transact must ask the backend for its
+	// authoritative nonce instead.
+	backend := &fakeBackend{wantNonce: 5}
+	c := NewBoundContract(ids.ID{}, testTransferABI(), backend)
+	session := NewSession(testKey(t), 1)
+
+	if _, err := c.Transact(context.Background(), session, "transfer"); err != nil {
+		t.Fatalf("Transact: %v", err)
+	}
+
+	if len(backend.nextNonces) != 1 {
+		t.Fatalf("NextNonce called %d times, want 1", len(backend.nextNonces))
+	}
+	if got := backend.gotNonces; len(got) != 2 || got[0] != 1 || got[1] != 5 {
+		t.Fatalf("Invoke called with nonces %v, want [1 5]", got)
+	}
+}
@@ -0,0 +1,131 @@
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
+)
+
+// tmplData is the root object handed to tmplSource.
+type tmplData struct {
+	Package     string
+	Name        string
+	ABIJSON     string
+	Methods     []tmplMethod
+	NeedsBigInt bool
+}
+
+// tmplMethod is one method's view within tmplData, precomputed so the
+// template itself stays free of logic beyond field access, range and
+// simple conditionals.
+type tmplMethod struct {
+	Name     string
+	GoName   string
+	Constant bool
+	Inputs   []tmplParam
+	Outputs  []tmplParam
+}
+
+// tmplParam is a single typed input or output, pre-mapped to its
+// concrete Go type so the generated method's signature is as
+// strongly-typed as the ABI allows.
+type tmplParam struct {
+	GoName string
+	GoType string
+}
+
+// Bind generates the Go source for a strongly-typed binding to a
+// contract named name, described by contractABI, as package pkg. The
+// result still needs go/format.Source applied by the caller (or
+// gofmt on disk) to look idiomatic; Bind itself only fills the
+// template.
+func Bind(name string, contractABI abi.ABI, abiJSON string, pkg string) ([]byte, error) {
+	tmpl, err := template.New("binding").Parse(tmplSource)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse binding template: %v", err)
+	}
+
+	data := tmplData{
+		Package: pkg,
+		Name:    exportedName(name),
+		ABIJSON: abiJSON,
+	}
+	for _, m := range contractABI.Methods {
+		method := tmplMethod{Name: m.Name, GoName: exportedName(m.Name), Constant: m.Constant}
+		for _, in := range m.Inputs {
+			goType := abiGoType(in.Type)
+			method.Inputs = append(method.Inputs, tmplParam{GoName: lowerFirst(exportedName(in.Name)), GoType: goType})
+			data.NeedsBigInt = data.NeedsBigInt || goType == "*big.Int"
+		}
+		for i, out := range m.Outputs {
+			goType := abiGoType(out.Type)
+			method.Outputs = append(method.Outputs, tmplParam{GoName: lowerFirst(exportedName(out.Name)) + fmt.Sprint(i), GoType: goType})
+			data.NeedsBigInt = data.NeedsBigInt || goType == "*big.Int"
+		}
+		data.Methods = append(data.Methods, method)
+	}
+	// Map iteration order is randomized; sort by name so running the
+	// generator twice on the same ABI produces byte-identical output.
+	sort.Slice(data.Methods, func(i, j int) bool { return data.Methods[i].Name < data.Methods[j].Name })
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("couldn't execute binding template: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportedName title-cases s so it's safe to use as an exported Go
+// identifier, e.g. "transfer" -> "Transfer", "token_id" -> "TokenId".
+func exportedName(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' })
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		r := []rune(p)
+		b.WriteRune(unicode.ToUpper(r[0]))
+		b.WriteString(string(r[1:]))
+	}
+	if b.Len() == 0 {
+		return "Arg"
+	}
+	return b.String()
+}
+
+// abiGoType maps an ABI Type to the concrete Go type Pack/Unpack use
+// for it, the way abigen maps Solidity types to Go types. Composite
+// types (arrays, slices, tuples) fall back to the untyped shapes
+// Unpack already produces for them, since a fully concrete mapping
+// would require generating a matching Go type per contract.
+func abiGoType(t abi.Type) string {
+	switch t.Kind {
+	case abi.UintTy, abi.IntTy:
+		return "*big.Int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy, abi.AddressTy:
+		return "string"
+	case abi.BytesTy, abi.FixedBytesTy:
+		return "[]byte"
+	case abi.TupleTy:
+		return "map[string]interface{}"
+	default: // SliceTy, ArrayTy
+		return "[]interface{}"
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
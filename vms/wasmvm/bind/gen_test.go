@@ -0,0 +1,60 @@
+package bind
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
+)
+
+const testTokenABIJSON = `[
+	{"name":"balanceOf","constant":true,"inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"balance","type":"uint256"}]},
+	{"name":"transfer","constant":false,"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"name":"allowance","constant":true,"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"remaining","type":"uint256"}]},
+	{"name":"name","constant":true,"inputs":[],"outputs":[{"name":"","type":"string"}]}
+]`
+
+func TestBindIsDeterministic(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(testTokenABIJSON))
+	if err != nil {
+		t.Fatalf("abi.JSON: %v", err)
+	}
+
+	first, err := Bind("Token", parsedABI, testTokenABIJSON, "main")
+	if err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := Bind("Token", parsedABI, testTokenABIJSON, "main")
+		if err != nil {
+			t.Fatalf("Bind: %v", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatalf("Bind produced different output on run %d:\n--- first ---\n%s\n--- again ---\n%s", i, first, again)
+		}
+	}
+}
+
+func TestBindTypesMethodSignatures(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(testTokenABIJSON))
+	if err != nil {
+		t.Fatalf("abi.JSON: %v", err)
+	}
+
+	src, err := Bind("Token", parsedABI, testTokenABIJSON, "main")
+	if err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	got := string(src)
+
+	for _, want := range []string{
+		`func (c *Token) BalanceOf(ctx context.Context, owner string) (*big.Int, error) {`,
+		`func (c *Token) Transfer(ctx context.Context, session *bind.Session, to string, amount *big.Int) (ids.ID, error) {`,
+		`"math/big"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("generated source missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package bind
+
+import (
+	"sync"
+
+	"github.com/ava-labs/gecko/utils/crypto"
+)
+
+// errNonceTooLow is returned by a node when a tx's nonce has already
+// been consumed by an earlier tx from the same sender. When
+// BoundContract.Transact sees this, it resets the Session's
+// NonceManager to the backend's NextNonce and retries once.
+const errNonceTooLow = "nonce too low"
+
+// NonceManager hands out successive nonces for a sender, so a Session
+// doesn't require the caller to track them by hand across calls.
+type NonceManager struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NewNonceManager returns a NonceManager that starts handing out
+// nonces at startAt.
+func NewNonceManager(startAt uint64) *NonceManager {
+	return &NonceManager{next: startAt}
+}
+
+// Take returns the next nonce to use and advances past it.
+func (n *NonceManager) Take() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nonce := n.next
+	n.next++
+	return nonce
+}
+
+// Reset makes the manager hand out nonces starting at startAt, which
+// may be before or after its current position; callers use this after
+// a transaction is rejected for a bad nonce.
+func (n *NonceManager) Reset(startAt uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.next = startAt
+}
+
+// Session bundles a signing key and a NonceManager so a generated
+// binding's transacting methods don't require the caller to thread a
+// nonce through every call.
+type Session struct {
+	Key    *crypto.PrivateKeySECP256K1R
+	Nonces *NonceManager
+}
+
+// NewSession returns a Session that signs with key and starts handing
+// out nonces at startNonce.
+func NewSession(key *crypto.PrivateKeySECP256K1R, startNonce uint64) *Session {
+	return &Session{Key: key, Nonces: NewNonceManager(startNonce)}
+}
@@ -0,0 +1,63 @@
+package bind
+
+// tmplSource is the text/template used by Bind to emit one Go struct
+// per contract, with one method per ABI entry. It intentionally
+// mirrors the shape of go-ethereum's bind template: a thin struct
+// wrapping a *bind.BoundContract, read-only methods calling Call, and
+// state-changing methods calling Transact.
+const tmplSource = `// Code generated by gecko-wasm-bind. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{if .NeedsBigInt}}	"math/big"
+{{end}}	"strings"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
+	"github.com/ava-labs/gecko/vms/wasmvm/bind"
+)
+
+// {{.Name}}ABI is the JSON ABI {{.Name}} was generated from.
+const {{.Name}}ABI = ` + "`{{.ABIJSON}}`" + `
+
+// {{.Name}} is a generated binding for the {{.Name}} contract.
+type {{.Name}} struct {
+	*bind.BoundContract
+}
+
+// New{{.Name}} binds {{.Name}} to a deployed contract at contractID.
+func New{{.Name}}(contractID ids.ID, backend bind.ContractBackend) (*{{.Name}}, error) {
+	parsedABI, err := abi.JSON(strings.NewReader({{.Name}}ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Name}}{BoundContract: bind.NewBoundContract(contractID, parsedABI, backend)}, nil
+}
+{{range .Methods}}
+{{if .Constant}}
+{{if eq (len .Outputs) 1}}
+// {{.GoName}} calls the read-only "{{.Name}}" method.
+func (c *{{$.Name}}) {{.GoName}}(ctx context.Context{{range .Inputs}}, {{.GoName}} {{.GoType}}{{end}}) ({{(index .Outputs 0).GoType}}, error) {
+	out, err := c.Call(ctx, "{{.Name}}"{{range .Inputs}}, {{.GoName}}{{end}})
+	if err != nil {
+		var zero {{(index .Outputs 0).GoType}}
+		return zero, err
+	}
+	return out[0].({{(index .Outputs 0).GoType}}), nil
+}
+{{else}}
+// {{.GoName}} calls the read-only "{{.Name}}" method.
+func (c *{{$.Name}}) {{.GoName}}(ctx context.Context{{range .Inputs}}, {{.GoName}} {{.GoType}}{{end}}) ([]interface{}, error) {
+	return c.Call(ctx, "{{.Name}}"{{range .Inputs}}, {{.GoName}}{{end}})
+}
+{{end}}
+{{else}}
+// {{.GoName}} submits a transaction invoking "{{.Name}}".
+func (c *{{$.Name}}) {{.GoName}}(ctx context.Context, session *bind.Session{{range .Inputs}}, {{.GoName}} {{.GoType}}{{end}}) (ids.ID, error) {
+	return c.Transact(ctx, session, "{{.Name}}"{{range .Inputs}}, {{.GoName}}{{end}})
+}
+{{end}}
+{{end}}
+`
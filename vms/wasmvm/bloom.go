@@ -0,0 +1,70 @@
+package wasmvm
+
+import "github.com/ava-labs/gecko/ids"
+
+// bloomBits is the width, in bits, of a per-block bloom filter. A
+// wider filter lowers the false-positive rate GetLogs pays for when
+// it scans a block that turns out to share a few bit positions with
+// the query but none of its actual contract/topic values.
+const bloomBits = 2048
+
+// blockBloom is a bloom filter over the contract IDs and log topics
+// that appeared in a single block, used by the log indexer to skip
+// blocks that can't possibly match a GetLogs filter without scanning
+// every log they contain.
+type blockBloom [bloomBits / 8]byte
+
+// addID sets this bloom's bits for id using three slices of its own
+// bytes as bit indices; ids.ID values are already hash output, so
+// reusing their bytes avoids needing a separate hash function.
+func (b *blockBloom) addID(id ids.ID) {
+	raw := id.Bytes()
+	for _, idx := range bloomIndices(raw) {
+		b[idx/8] |= 1 << (idx % 8)
+	}
+}
+
+// mayContainID reports whether id's bits are all set. A false result
+// means id is definitely absent; a true result means it's probably
+// present.
+func (b *blockBloom) mayContainID(id ids.ID) bool {
+	raw := id.Bytes()
+	for _, idx := range bloomIndices(raw) {
+		if b[idx/8]&(1<<(idx%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomIndices derives 3 bit positions from raw, taken from
+// non-overlapping 4-byte windows near the start, middle and end so a
+// short input still spreads its bits across the filter.
+func bloomIndices(raw []byte) [3]uint32 {
+	var idx [3]uint32
+	windows := [3]int{0, len(raw) / 2, len(raw) - 4}
+	for i, start := range windows {
+		if start < 0 {
+			start = 0
+		}
+		if start+4 > len(raw) {
+			start = len(raw) - 4
+			if start < 0 {
+				start = 0
+			}
+		}
+		var v uint32
+		for _, bb := range raw[start:min(start+4, len(raw))] {
+			v = v<<8 | uint32(bb)
+		}
+		idx[i] = v % bloomBits
+	}
+	return idx
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
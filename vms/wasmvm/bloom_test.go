@@ -0,0 +1,42 @@
+package wasmvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func idFromByte(b byte) ids.ID {
+	var raw [32]byte
+	raw[0] = b
+	raw[31] = b
+	return ids.NewID(raw)
+}
+
+func TestBlockBloomAddAndMayContain(t *testing.T) {
+	var bloom blockBloom
+	present := idFromByte(1)
+	absent := idFromByte(2)
+
+	bloom.addID(present)
+
+	if !bloom.mayContainID(present) {
+		t.Fatal("mayContainID false negative for an added ID")
+	}
+	if bloom.mayContainID(absent) {
+		t.Fatal("mayContainID false positive for an ID that was never added")
+	}
+}
+
+func TestBlockBloomAccumulates(t *testing.T) {
+	var bloom blockBloom
+	ids := []ids.ID{idFromByte(3), idFromByte(4), idFromByte(5)}
+	for _, id := range ids {
+		bloom.addID(id)
+	}
+	for _, id := range ids {
+		if !bloom.mayContainID(id) {
+			t.Fatalf("mayContainID false negative for %s after adding all IDs", id)
+		}
+	}
+}
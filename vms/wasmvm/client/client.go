@@ -0,0 +1,165 @@
+// Package client implements a Go client for the wasmvm JSON-RPC
+// service, mirroring the pattern used by coreth's plugin/evm/client.go:
+// a thin typed wrapper around gecko/utils/rpc that hides CB58 encoding
+// and key-bytes conversion from callers.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/json"
+	"github.com/ava-labs/gecko/utils/rpc"
+	"github.com/ava-labs/gecko/vms/wasmvm"
+)
+
+// Interface is the client API for the wasmvm service.
+type Interface interface {
+	// NewKey asks the node to generate a new private key.
+	NewKey(ctx context.Context) (*crypto.PrivateKeySECP256K1R, error)
+
+	// CreateContract submits a tx creating a new contract from
+	// wasmBytes, signed by key using nonce, and returns the new
+	// contract's ID.
+	CreateContract(ctx context.Context, wasmBytes []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error)
+
+	// CreateContractWithABI is CreateContract plus a JSON ABI that
+	// the contract is registered under (see wasmvm.Service.GetContractABI).
+	CreateContractWithABI(ctx context.Context, wasmBytes []byte, abiJSON []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error)
+
+	// Invoke submits a tx invoking fn on contractID, signed by key
+	// using nonce, and returns the new tx's ID.
+	Invoke(ctx context.Context, contractID ids.ID, fn string, args []wasmvm.ArgAPI, byteArgs []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error)
+
+	// GetTx fetches the receipt for a previously-submitted tx.
+	GetTx(ctx context.Context, txID ids.ID) (*wasmvm.GetTxResponse, error)
+
+	// WaitForTx polls GetTx until txID's receipt is available, the
+	// context is cancelled, or timeout elapses.
+	WaitForTx(ctx context.Context, txID ids.ID, timeout time.Duration) (*wasmvm.GetTxResponse, error)
+
+	// NextNonce returns the next nonce this client believes is unused
+	// for address, and reserves it. Callers invoking concurrently for
+	// the same sender should use this instead of tracking nonces
+	// themselves.
+	NextNonce(address string) uint64
+}
+
+// client is the concrete Interface implementation.
+type client struct {
+	requester rpc.EndpointRequester
+	nonces    *nonceManager
+}
+
+// NewClient returns a client for the wasmvm instance of chain running
+// on the node at uri.
+func NewClient(uri, chain string, requestTimeout time.Duration) Interface {
+	return &client{
+		requester: rpc.NewEndpointRequester(uri, fmt.Sprintf("/ext/bc/%s", chain), "wasm", requestTimeout),
+		nonces:    newNonceManager(),
+	}
+}
+
+func (c *client) NewKey(ctx context.Context) (*crypto.PrivateKeySECP256K1R, error) {
+	res := &wasmvm.NewKeyResponse{}
+	if err := c.requester.SendRequest("newKey", struct{}{}, res); err != nil {
+		return nil, fmt.Errorf("couldn't request new key: %v", err)
+	}
+	return parsePrivateKey(res.Key.Bytes)
+}
+
+func (c *client) CreateContract(ctx context.Context, wasmBytes []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error) {
+	return c.createContract(wasmBytes, nil, key, nonce)
+}
+
+func (c *client) CreateContractWithABI(ctx context.Context, wasmBytes []byte, abiJSON []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error) {
+	return c.createContract(wasmBytes, abiJSON, key, nonce)
+}
+
+func (c *client) createContract(wasmBytes []byte, abiJSON []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error) {
+	args := &wasmvm.CreateContractArgs{
+		Contract:    formatting.CB58{Bytes: wasmBytes},
+		SenderKey:   formatting.CB58{Bytes: key.Bytes()},
+		SenderNonce: json.Uint64(nonce),
+		ABI:         abiJSON,
+	}
+	var contractID ids.ID
+	if err := c.requester.SendRequest("createContract", args, &contractID); err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't request createContract: %v", err)
+	}
+	return contractID, nil
+}
+
+func (c *client) Invoke(ctx context.Context, contractID ids.ID, fn string, args []wasmvm.ArgAPI, byteArgs []byte, key *crypto.PrivateKeySECP256K1R, nonce uint64) (ids.ID, error) {
+	invokeArgs := &wasmvm.InvokeArgs{
+		ContractID:  contractID,
+		Function:    fn,
+		SenderKey:   formatting.CB58{Bytes: key.Bytes()},
+		SenderNonce: json.Uint64(nonce),
+		Args:        args,
+	}
+	if len(byteArgs) > 0 {
+		invokeArgs.ByteArgs = formatting.CB58{Bytes: byteArgs}.String()
+	}
+
+	res := &wasmvm.InvokeResponse{}
+	if err := c.requester.SendRequest("invoke", invokeArgs, res); err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't request invoke: %v", err)
+	}
+	return res.TxID, nil
+}
+
+func (c *client) GetTx(ctx context.Context, txID ids.ID) (*wasmvm.GetTxResponse, error) {
+	res := &wasmvm.GetTxResponse{}
+	args := &wasmvm.GetTxArgs{ID: txID}
+	if err := c.requester.SendRequest("getTx", args, res); err != nil {
+		return nil, fmt.Errorf("couldn't request getTx: %v", err)
+	}
+	return res, nil
+}
+
+func (c *client) WaitForTx(ctx context.Context, txID ids.ID, timeout time.Duration) (*wasmvm.GetTxResponse, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		res, err := c.GetTx(ctx, txID)
+		if err == nil && res.Tx != nil {
+			return res, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for tx %s", txID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func (c *client) NextNonce(address string) uint64 {
+	return c.nonces.take(address)
+}
+
+func parsePrivateKey(bytes []byte) (*crypto.PrivateKeySECP256K1R, error) {
+	factory := crypto.FactorySECP256K1R{}
+	keyIntf, err := factory.ToPrivateKey(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse private key: %v", err)
+	}
+	key, ok := keyIntf.(*crypto.PrivateKeySECP256K1R)
+	if !ok {
+		return nil, fmt.Errorf("couldn't parse private key: not a SECP256K1R key")
+	}
+	return key, nil
+}
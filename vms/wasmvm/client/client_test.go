@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// fakeRequester is an rpc.EndpointRequester that answers getTx calls
+// with no receipt until it has been called readyAfter times,
+// after which it reports a (zero-value) receipt as available. It lets
+// WaitForTx's poll loop be exercised without a live node.
+type fakeRequester struct {
+	calls      int
+	readyAfter int
+	sendErr    error
+}
+
+func (f *fakeRequester) SendRequest(method string, _, reply interface{}) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	if method != "getTx" {
+		return errors.New("fakeRequester only handles getTx")
+	}
+	f.calls++
+	if f.calls >= f.readyAfter {
+		return json.Unmarshal([]byte(`{"receipt":{}}`), reply)
+	}
+	return json.Unmarshal([]byte(`{}`), reply)
+}
+
+func newTestClient(requester *fakeRequester) *client {
+	return &client{requester: requester, nonces: newNonceManager()}
+}
+
+func TestWaitForTxPollsUntilReceiptIsAvailable(t *testing.T) {
+	requester := &fakeRequester{readyAfter: 3}
+	c := newTestClient(requester)
+
+	res, err := c.WaitForTx(context.Background(), ids.ID{}, time.Second)
+	if err != nil {
+		t.Fatalf("WaitForTx: %v", err)
+	}
+	if res.Tx == nil {
+		t.Fatal("WaitForTx returned a response with no receipt")
+	}
+	if requester.calls != 3 {
+		t.Fatalf("getTx called %d times, want 3 (polled until ready)", requester.calls)
+	}
+}
+
+func TestWaitForTxTimesOut(t *testing.T) {
+	requester := &fakeRequester{readyAfter: 1000}
+	c := newTestClient(requester)
+
+	_, err := c.WaitForTx(context.Background(), ids.ID{}, 120*time.Millisecond)
+	if err == nil {
+		t.Fatal("WaitForTx should time out when the receipt never becomes available")
+	}
+}
+
+func TestWaitForTxRespectsContextCancellation(t *testing.T) {
+	requester := &fakeRequester{readyAfter: 1000}
+	c := newTestClient(requester)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.WaitForTx(ctx, ids.ID{}, time.Second)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("WaitForTx error = %v, want context.Canceled", err)
+	}
+}
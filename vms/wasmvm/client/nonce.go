@@ -0,0 +1,29 @@
+package client
+
+import "sync"
+
+// nonceManager hands out successive nonces per sender address, so
+// concurrent callers sharing a client don't have to coordinate
+// senderNonce themselves.
+type nonceManager struct {
+	mu   sync.Mutex
+	next map[string]uint64
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{next: make(map[string]uint64)}
+}
+
+// take returns the next unused nonce for address and reserves it.
+// Nonces start at 1, matching the service's requirement that
+// senderNonce be at least 1.
+func (n *nonceManager) take(address string) uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nonce, ok := n.next[address]
+	if !ok {
+		nonce = 1
+	}
+	n.next[address] = nonce + 1
+	return nonce
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNonceManagerTakeStartsAtOneAndIncrements(t *testing.T) {
+	n := newNonceManager()
+	const addr = "addr1"
+
+	if got := n.take(addr); got != 1 {
+		t.Fatalf("first take() = %d, want 1", got)
+	}
+	if got := n.take(addr); got != 2 {
+		t.Fatalf("second take() = %d, want 2", got)
+	}
+}
+
+func TestNonceManagerTakeIsPerAddress(t *testing.T) {
+	n := newNonceManager()
+
+	if got := n.take("addr1"); got != 1 {
+		t.Fatalf("take(addr1) = %d, want 1", got)
+	}
+	if got := n.take("addr2"); got != 1 {
+		t.Fatalf("take(addr2) = %d, want 1 (independent of addr1)", got)
+	}
+	if got := n.take("addr1"); got != 2 {
+		t.Fatalf("take(addr1) = %d, want 2", got)
+	}
+}
+
+func TestNonceManagerTakeIsSafeForConcurrentUse(t *testing.T) {
+	n := newNonceManager()
+	const addr = "addr1"
+	const callers = 50
+
+	var wg sync.WaitGroup
+	nonces := make([]uint64, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			nonces[i] = n.take(addr)
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[uint64]bool, callers)
+	for _, nonce := range nonces {
+		if seen[nonce] {
+			t.Fatalf("nonce %d handed out more than once", nonce)
+		}
+		seen[nonce] = true
+	}
+}
@@ -0,0 +1,17 @@
+package wasmvm
+
+import (
+	"crypto/rand"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// newFilterID returns a fresh random ID to name a NewFilter
+// registration or a Subscribe subscription. These IDs are never
+// persisted or gossiped, so a plain random value (rather than one
+// derived from consensus state) is sufficient.
+func newFilterID() ids.ID {
+	var b [32]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read on this byte count never returns an error
+	return ids.NewID(b)
+}
@@ -0,0 +1,86 @@
+package wasmvm
+
+import "errors"
+
+// ErrOutOfGas is returned by a metered interpreter call once it has
+// charged more gas than its Meter's budget allows. Call and
+// EstimateGas both treat it as a distinct, expected failure mode
+// rather than a generic execution error.
+var ErrOutOfGas = errors.New("out of gas")
+
+// GasCostTable assigns a per-instruction-family cost, used by the
+// metered interpreter to charge gas as a contract executes.
+type GasCostTable struct {
+	// Arithmetic is charged per arithmetic/comparison/logic
+	// instruction (add, mul, lt, and, ...).
+	Arithmetic uint64
+	// Memory is charged per linear-memory load or store.
+	Memory uint64
+	// Call is charged per function call, direct or indirect.
+	Call uint64
+	// GrowMemoryPerPage is charged per WASM page (64KiB) a
+	// memory.grow instruction adds.
+	GrowMemoryPerPage uint64
+}
+
+// DefaultGasCostTable is the cost table Call and EstimateGas use
+// unless a caller supplies their own.
+var DefaultGasCostTable = GasCostTable{
+	Arithmetic:        1,
+	Memory:            3,
+	Call:              10,
+	GrowMemoryPerPage: 256,
+}
+
+// DefaultGasBudget is the gas budget Call uses when a request doesn't
+// specify one.
+const DefaultGasBudget = 1000000
+
+// maxGasEstimateSearch bounds EstimateGas's binary search so a
+// contract that can never succeed doesn't make the search run away.
+const maxGasEstimateSearch = 10000000
+
+// GasMeter tracks gas consumption against a fixed budget over the
+// course of a single metered call.
+type GasMeter struct {
+	Table  GasCostTable
+	Budget uint64
+	used   uint64
+}
+
+// NewGasMeter returns a GasMeter with the given budget and cost
+// table.
+func NewGasMeter(budget uint64, table GasCostTable) *GasMeter {
+	return &GasMeter{Table: table, Budget: budget}
+}
+
+// Charge deducts cost from the meter's remaining budget, returning
+// ErrOutOfGas if doing so would exceed it. On ErrOutOfGas, Used()
+// reports the full budget, since the caller should stop executing at
+// that point.
+func (m *GasMeter) Charge(cost uint64) error {
+	if m.used+cost > m.Budget {
+		m.used = m.Budget
+		return ErrOutOfGas
+	}
+	m.used += cost
+	return nil
+}
+
+// ChargeArithmetic charges for a single arithmetic/comparison/logic
+// instruction.
+func (m *GasMeter) ChargeArithmetic() error { return m.Charge(m.Table.Arithmetic) }
+
+// ChargeMemory charges for a single linear-memory load or store.
+func (m *GasMeter) ChargeMemory() error { return m.Charge(m.Table.Memory) }
+
+// ChargeCall charges for a single function call.
+func (m *GasMeter) ChargeCall() error { return m.Charge(m.Table.Call) }
+
+// ChargeGrowMemory charges for growing linear memory by pages pages.
+func (m *GasMeter) ChargeGrowMemory(pages uint64) error {
+	return m.Charge(m.Table.GrowMemoryPerPage * pages)
+}
+
+// Used returns the gas charged so far.
+func (m *GasMeter) Used() uint64 { return m.used }
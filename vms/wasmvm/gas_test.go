@@ -0,0 +1,62 @@
+package wasmvm
+
+import "testing"
+
+func TestGasMeterChargeWithinBudget(t *testing.T) {
+	m := NewGasMeter(100, DefaultGasCostTable)
+	if err := m.Charge(40); err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+	if err := m.Charge(40); err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+	if got, want := m.Used(), uint64(80); got != want {
+		t.Fatalf("Used() = %d, want %d", got, want)
+	}
+}
+
+func TestGasMeterChargeOutOfGas(t *testing.T) {
+	m := NewGasMeter(100, DefaultGasCostTable)
+	if err := m.Charge(60); err != nil {
+		t.Fatalf("Charge: %v", err)
+	}
+	if err := m.Charge(60); err != ErrOutOfGas {
+		t.Fatalf("Charge over budget = %v, want ErrOutOfGas", err)
+	}
+	if got, want := m.Used(), uint64(100); got != want {
+		t.Fatalf("Used() after ErrOutOfGas = %d, want full budget %d", got, want)
+	}
+}
+
+func TestGasMeterChargeHelpers(t *testing.T) {
+	table := GasCostTable{Arithmetic: 1, Memory: 3, Call: 10, GrowMemoryPerPage: 256}
+	m := NewGasMeter(1000, table)
+
+	if err := m.ChargeArithmetic(); err != nil {
+		t.Fatalf("ChargeArithmetic: %v", err)
+	}
+	if err := m.ChargeMemory(); err != nil {
+		t.Fatalf("ChargeMemory: %v", err)
+	}
+	if err := m.ChargeCall(); err != nil {
+		t.Fatalf("ChargeCall: %v", err)
+	}
+	if err := m.ChargeGrowMemory(2); err != nil {
+		t.Fatalf("ChargeGrowMemory: %v", err)
+	}
+
+	want := table.Arithmetic + table.Memory + table.Call + table.GrowMemoryPerPage*2
+	if got := m.Used(); got != want {
+		t.Fatalf("Used() = %d, want %d", got, want)
+	}
+}
+
+func TestGasMeterChargeExactBudgetSucceeds(t *testing.T) {
+	m := NewGasMeter(50, DefaultGasCostTable)
+	if err := m.Charge(50); err != nil {
+		t.Fatalf("Charge up to exactly the budget should succeed: %v", err)
+	}
+	if err := m.Charge(1); err != ErrOutOfGas {
+		t.Fatalf("Charge past a fully-spent budget = %v, want ErrOutOfGas", err)
+	}
+}
@@ -0,0 +1,86 @@
+package wasmvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// idSize is the width, in bytes, of a single topic. Encoding topics
+// as exactly 32 bytes (an ids.ID) keeps filter matching a plain byte
+// compare, with no variable-length parsing.
+const idSize = 32
+
+// HostLogContext carries what a running contract's gecko_log calls
+// need in scope: which contract and tx they're for, the block they'll
+// be attributed to, and the index to record into. The host runtime
+// constructs one per invocation and exposes HostLog as the contract's
+// gecko_log import.
+type HostLogContext struct {
+	ContractID  ids.ID
+	TxID        ids.ID
+	BlockHeight uint64
+	Index       *LogIndex
+
+	nextLogIndex uint32
+}
+
+// HostLog implements the gecko_log(topicsPtr, topicsLen, dataPtr,
+// dataLen) host function exposed to WASM contracts. topicsLen and
+// dataLen are byte counts; topicsLen must be a multiple of idSize (up
+// to 4 topics, matching Ethereum's LOG0-LOG4 convention). mem is the
+// calling instance's linear memory.
+func (c *HostLogContext) HostLog(mem []byte, topicsPtr, topicsLen, dataPtr, dataLen int32) error {
+	if topicsLen%idSize != 0 {
+		return fmt.Errorf("gecko_log: topicsLen %d is not a multiple of %d", topicsLen, idSize)
+	}
+	numTopics := int(topicsLen) / idSize
+	if numTopics > 4 {
+		return fmt.Errorf("gecko_log: got %d topics, max is 4", numTopics)
+	}
+
+	topicBytes, err := readMemory(mem, topicsPtr, topicsLen)
+	if err != nil {
+		return fmt.Errorf("gecko_log: couldn't read topics: %v", err)
+	}
+	data, err := readMemory(mem, dataPtr, dataLen)
+	if err != nil {
+		return fmt.Errorf("gecko_log: couldn't read data: %v", err)
+	}
+
+	topics := make([]ids.ID, numTopics)
+	for i := 0; i < numTopics; i++ {
+		var raw [idSize]byte
+		copy(raw[:], topicBytes[i*idSize:(i+1)*idSize])
+		topics[i] = ids.NewID(raw)
+	}
+
+	// Copy data so the log record doesn't alias the contract's
+	// linear memory, which it's free to mutate or grow afterwards.
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+
+	c.Index.Add(Log{
+		ContractID:  c.ContractID,
+		Topics:      topics,
+		Data:        dataCopy,
+		BlockHeight: c.BlockHeight,
+		TxID:        c.TxID,
+		LogIndex:    c.nextLogIndex,
+	})
+	c.nextLogIndex++
+	return nil
+}
+
+// readMemory returns the ptr:ptr+length slice of mem, bounds-checked
+// since ptr/length are attacker-controlled WASM-side values.
+func readMemory(mem []byte, ptr, length int32) ([]byte, error) {
+	if ptr < 0 || length < 0 {
+		return nil, fmt.Errorf("negative pointer or length")
+	}
+	end := int(ptr) + int(length)
+	if end > len(mem) {
+		return nil, fmt.Errorf("out of bounds: [%d:%d] exceeds memory of size %d", ptr, end, len(mem))
+	}
+	return mem[ptr:end], nil
+}
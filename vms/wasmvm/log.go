@@ -0,0 +1,26 @@
+package wasmvm
+
+import (
+	"github.com/ava-labs/gecko/ids"
+)
+
+// Log is a single event record emitted by a contract during a tx's
+// execution, via the gecko_log(topicsPtr, topicsLen, dataPtr, dataLen)
+// host function exposed to running WASM contracts.
+type Log struct {
+	// ContractID is the contract that emitted this log.
+	ContractID ids.ID `json:"contractID"`
+	// Topics are up to 4 indexed 32-byte values a client can filter
+	// on, most-significant first (topics[0] is conventionally the
+	// event signature).
+	Topics []ids.ID `json:"topics"`
+	// Data is the non-indexed event payload.
+	Data []byte `json:"data"`
+	// BlockHeight is the height of the block the emitting tx was
+	// accepted in.
+	BlockHeight uint64 `json:"blockHeight"`
+	// TxID is the tx that emitted this log.
+	TxID ids.ID `json:"txID"`
+	// LogIndex is this log's position within its tx's Logs slice.
+	LogIndex uint32 `json:"logIndex"`
+}
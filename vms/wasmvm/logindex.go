@@ -0,0 +1,246 @@
+package wasmvm
+
+import (
+	"sync"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// LogFilter describes which logs GetLogs, NewFilter and Subscribe
+// should match. ContractIDs and each entry of Topics are OR'd
+// together; the positions of Topics are AND'd (an empty position
+// matches any topic there, i.e. a wildcard).
+type LogFilter struct {
+	ContractIDs []ids.ID   `json:"contractIDs,omitempty"`
+	Topics      [][]ids.ID `json:"topics,omitempty"`
+	FromBlock   uint64     `json:"fromBlock"`
+	// ToBlock of 0 means "no upper bound".
+	ToBlock uint64 `json:"toBlock,omitempty"`
+}
+
+// matches reports whether log satisfies f.
+func (f *LogFilter) matches(log *Log) bool {
+	if log.BlockHeight < f.FromBlock {
+		return false
+	}
+	if f.ToBlock != 0 && log.BlockHeight > f.ToBlock {
+		return false
+	}
+	if len(f.ContractIDs) > 0 {
+		found := false
+		for _, id := range f.ContractIDs {
+			if id.Equals(log.ContractID) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for pos, wanted := range f.Topics {
+		if len(wanted) == 0 {
+			continue // wildcard position
+		}
+		if pos >= len(log.Topics) {
+			return false
+		}
+		found := false
+		for _, want := range wanted {
+			if want.Equals(log.Topics[pos]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// logFilterSubscriber receives every log accepted after it registered
+// that its LogFilter matches. It backs both NewFilter (polling, via
+// GetFilterChanges) and Subscribe (push, via a websocket).
+type logFilterSubscriber struct {
+	filter  LogFilter
+	pending []Log // logs matched since the last drain, for NewFilter
+	push    chan<- Log
+}
+
+// LogIndex is the per-VM store of every log ever accepted. Add keeps
+// a per-topic reverse index (byTopic) and a bloom filter per block.
+// Query consults only the bloom filters and still visits every stored
+// log, so its cost grows with the total number of logs; byTopic is
+// not read by any query.
+type LogIndex struct {
+	mu sync.RWMutex
+
+	logs        []Log
+	byTopic     map[ids.ID][]int // topic -> indices into logs
+	blockBlooms map[uint64]*blockBloom
+
+	filters map[ids.ID]*logFilterSubscriber
+}
+
+// NewLogIndex returns an empty LogIndex.
+func NewLogIndex() *LogIndex {
+	return &LogIndex{
+		byTopic:     make(map[ids.ID][]int),
+		blockBlooms: make(map[uint64]*blockBloom),
+		filters:     make(map[ids.ID]*logFilterSubscriber),
+	}
+}
+
+// Add records log in the index and fans it out to every registered
+// filter/subscription it matches. It's called once per log, in order,
+// as blocks are accepted.
+func (idx *LogIndex) Add(log Log) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	i := len(idx.logs)
+	idx.logs = append(idx.logs, log)
+
+	bloom := idx.blockBlooms[log.BlockHeight]
+	if bloom == nil {
+		bloom = &blockBloom{}
+		idx.blockBlooms[log.BlockHeight] = bloom
+	}
+	bloom.addID(log.ContractID)
+	for _, topic := range log.Topics {
+		idx.byTopic[topic] = append(idx.byTopic[topic], i)
+		bloom.addID(topic)
+	}
+
+	for _, sub := range idx.filters {
+		if !sub.filter.matches(&log) {
+			continue
+		}
+		if sub.push == nil {
+			sub.pending = append(sub.pending, log)
+			continue
+		}
+		select {
+		case sub.push <- log:
+		default: // slow subscriber; drop rather than block block acceptance
+		}
+	}
+}
+
+// Query returns every indexed log matching filter, in insertion
+// order. It visits each stored log, and skips the full filter
+// comparison for logs whose block's bloom filter proves the block
+// can't contain a match.
+func (idx *LogIndex) Query(filter LogFilter) []Log {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var out []Log
+	for i := range idx.logs {
+		log := &idx.logs[i]
+		if bloom := idx.blockBlooms[log.BlockHeight]; bloom != nil && !bloomMayMatch(bloom, filter) {
+			continue
+		}
+		if filter.matches(log) {
+			out = append(out, *log)
+		}
+	}
+	return out
+}
+
+// bloomMayMatch reports whether a block's bloom filter is consistent
+// with filter possibly matching something in that block.
+func bloomMayMatch(bloom *blockBloom, filter LogFilter) bool {
+	if len(filter.ContractIDs) > 0 {
+		any := false
+		for _, id := range filter.ContractIDs {
+			if bloom.mayContainID(id) {
+				any = true
+				break
+			}
+		}
+		if !any {
+			return false
+		}
+	}
+	for _, wanted := range filter.Topics {
+		if len(wanted) == 0 {
+			continue
+		}
+		any := false
+		for _, id := range wanted {
+			if bloom.mayContainID(id) {
+				any = true
+				break
+			}
+		}
+		if !any {
+			return false
+		}
+	}
+	return true
+}
+
+// LogsForTx returns every log emitted by txID, in emission order.
+func (idx *LogIndex) LogsForTx(txID ids.ID) []Log {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var out []Log
+	for i := range idx.logs {
+		if idx.logs[i].TxID.Equals(txID) {
+			out = append(out, idx.logs[i])
+		}
+	}
+	return out
+}
+
+// NewFilter registers filter under id. Matching logs accepted
+// afterwards accumulate until drained by FilterChanges.
+func (idx *LogIndex) NewFilter(id ids.ID, filter LogFilter) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.filters[id] = &logFilterSubscriber{filter: filter}
+}
+
+// Subscribe registers filter and returns a channel that receives each
+// matching log as it's accepted, for a push-based (websocket)
+// consumer. Unsubscribe must be called to release it.
+func (idx *LogIndex) Subscribe(id ids.ID, filter LogFilter) <-chan Log {
+	ch := make(chan Log, 64)
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.filters[id] = &logFilterSubscriber{filter: filter, push: ch}
+	return ch
+}
+
+// Unsubscribe removes a filter or subscription, closing its push
+// channel if it had one.
+func (idx *LogIndex) Unsubscribe(id ids.ID) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	sub, ok := idx.filters[id]
+	if !ok {
+		return false
+	}
+	if sub.push != nil {
+		close(sub.push)
+	}
+	delete(idx.filters, id)
+	return true
+}
+
+// FilterChanges drains and returns the logs a NewFilter registration
+// has accumulated since the last call.
+func (idx *LogIndex) FilterChanges(id ids.ID) ([]Log, bool) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	sub, ok := idx.filters[id]
+	if !ok {
+		return nil, false
+	}
+	changes := sub.pending
+	sub.pending = nil
+	return changes, true
+}
@@ -0,0 +1,128 @@
+package wasmvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestLogFilterMatches(t *testing.T) {
+	contractA := idFromByte(0x10)
+	contractB := idFromByte(0x11)
+	topicTransfer := idFromByte(0x20)
+	topicOther := idFromByte(0x21)
+
+	log := &Log{
+		ContractID:  contractA,
+		Topics:      []ids.ID{topicTransfer},
+		BlockHeight: 5,
+	}
+
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   bool
+	}{
+		{"no constraints matches anything", LogFilter{}, true},
+		{"matching contract", LogFilter{ContractIDs: []ids.ID{contractA}}, true},
+		{"non-matching contract", LogFilter{ContractIDs: []ids.ID{contractB}}, false},
+		{"matching topic", LogFilter{Topics: [][]ids.ID{{topicTransfer}}}, true},
+		{"non-matching topic", LogFilter{Topics: [][]ids.ID{{topicOther}}}, false},
+		{"wildcard topic position", LogFilter{Topics: [][]ids.ID{{}}}, true},
+		{"below fromBlock", LogFilter{FromBlock: 6}, false},
+		{"above toBlock", LogFilter{ToBlock: 4}, false},
+		{"within range", LogFilter{FromBlock: 1, ToBlock: 10}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(log); got != tt.want {
+				t.Fatalf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogIndexQuerySkipsNonMatchingBlocks(t *testing.T) {
+	idx := NewLogIndex()
+	contractA := idFromByte(0x30)
+	contractB := idFromByte(0x31)
+
+	idx.Add(Log{ContractID: contractA, BlockHeight: 1})
+	idx.Add(Log{ContractID: contractB, BlockHeight: 2})
+	idx.Add(Log{ContractID: contractA, BlockHeight: 3})
+
+	got := idx.Query(LogFilter{ContractIDs: []ids.ID{contractA}})
+	if len(got) != 2 {
+		t.Fatalf("got %d logs, want 2", len(got))
+	}
+	for _, log := range got {
+		if !log.ContractID.Equals(contractA) {
+			t.Fatalf("got log for contract %s, want %s", log.ContractID, contractA)
+		}
+	}
+}
+
+func TestLogIndexSubscribeReceivesMatchingLogs(t *testing.T) {
+	idx := NewLogIndex()
+	contract := idFromByte(0x40)
+	subID := idFromByte(0x41)
+
+	ch := idx.Subscribe(subID, LogFilter{ContractIDs: []ids.ID{contract}})
+
+	idx.Add(Log{ContractID: idFromByte(0x42), BlockHeight: 1}) // shouldn't match
+	idx.Add(Log{ContractID: contract, BlockHeight: 2})
+
+	select {
+	case log := <-ch:
+		if !log.ContractID.Equals(contract) {
+			t.Fatalf("got log for contract %s, want %s", log.ContractID, contract)
+		}
+	default:
+		t.Fatal("expected a log on the subscription channel")
+	}
+
+	if !idx.Unsubscribe(subID) {
+		t.Fatal("Unsubscribe returned false for a live subscription")
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("channel should be closed after Unsubscribe")
+	}
+}
+
+func TestLogIndexFilterChangesDrainsOnce(t *testing.T) {
+	idx := NewLogIndex()
+	contract := idFromByte(0x50)
+	filterID := idFromByte(0x51)
+
+	idx.NewFilter(filterID, LogFilter{ContractIDs: []ids.ID{contract}})
+	idx.Add(Log{ContractID: contract, BlockHeight: 1})
+
+	changes, ok := idx.FilterChanges(filterID)
+	if !ok || len(changes) != 1 {
+		t.Fatalf("got %d changes (ok=%v), want 1", len(changes), ok)
+	}
+
+	changes, ok = idx.FilterChanges(filterID)
+	if !ok || len(changes) != 0 {
+		t.Fatalf("second drain got %d changes, want 0", len(changes))
+	}
+}
+
+func TestLogIndexLogsForTx(t *testing.T) {
+	idx := NewLogIndex()
+	txA := idFromByte(0x60)
+	txB := idFromByte(0x61)
+
+	idx.Add(Log{TxID: txA, LogIndex: 0})
+	idx.Add(Log{TxID: txB, LogIndex: 0})
+	idx.Add(Log{TxID: txA, LogIndex: 1})
+
+	got := idx.LogsForTx(txA)
+	if len(got) != 2 {
+		t.Fatalf("got %d logs, want 2", len(got))
+	}
+	if got[0].LogIndex != 0 || got[1].LogIndex != 1 {
+		t.Fatalf("logs not in emission order: %+v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package wasmvm
+
+import "fmt"
+
+// PreparedArg marks a fnArg produced from a composite ABI value (an
+// array, tuple, or anything else packed by the abi package) that
+// still needs to be placed in a WASM instance's linear memory before
+// a call: Blob is what PlaceArgs writes there, in exchange for the
+// i32 pointer that's actually passed across the call boundary.
+// Scalar args (int32, int64) never need this - they're handed to the
+// interpreter as-is.
+type PreparedArg struct {
+	Blob []byte
+}
+
+// LinearMemory is the minimal surface the host runtime needs to place
+// a PreparedArg into a WASM instance's memory: room to grow it, and a
+// place to write into what was grown.
+type LinearMemory interface {
+	// Grow adds deltaPages pages (64KiB each) of linear memory and
+	// returns the byte offset the new pages start at.
+	Grow(deltaPages int32) (offset int32, err error)
+	// Write copies data into memory starting at offset. The caller
+	// is responsible for offset/len(data) having been reserved by a
+	// prior Grow.
+	Write(offset int32, data []byte) error
+}
+
+// wasmPageSize is the size, in bytes, of one unit of WASM linear
+// memory growth.
+const wasmPageSize = 1 << 16
+
+// PlaceArgs is the memory-layout glue between the ABI pack/unpack
+// machinery and the WASM call boundary: it's run by the host runtime
+// immediately before invoking a contract function, after fnArgs have
+// already been produced by ArgAPI.toFnArg. Every *PreparedArg is
+// written into mem as a 4-byte big-endian length prefix followed by
+// its Blob, and replaced in the returned slice by the i32 pointer to
+// where it landed; every other value (the int32/int64 scalars WASM
+// accepts directly) passes through unchanged.
+func PlaceArgs(mem LinearMemory, fnArgs []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, len(fnArgs))
+	for i, arg := range fnArgs {
+		prepared, ok := arg.(*PreparedArg)
+		if !ok {
+			out[i] = arg
+			continue
+		}
+
+		encoded := make([]byte, 4+len(prepared.Blob))
+		encoded[0] = byte(len(prepared.Blob) >> 24)
+		encoded[1] = byte(len(prepared.Blob) >> 16)
+		encoded[2] = byte(len(prepared.Blob) >> 8)
+		encoded[3] = byte(len(prepared.Blob))
+		copy(encoded[4:], prepared.Blob)
+
+		pages := int32((len(encoded) + wasmPageSize - 1) / wasmPageSize)
+		if pages == 0 {
+			pages = 1
+		}
+		ptr, err := mem.Grow(pages)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't grow memory for arg %d: %v", i, err)
+		}
+		if err := mem.Write(ptr, encoded); err != nil {
+			return nil, fmt.Errorf("couldn't write arg %d to memory: %v", i, err)
+		}
+		out[i] = ptr
+	}
+	return out, nil
+}
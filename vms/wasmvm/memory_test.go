@@ -0,0 +1,59 @@
+package wasmvm
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// fakeLinearMemory is a growable byte buffer standing in for a WASM
+// instance's linear memory in tests.
+type fakeLinearMemory struct {
+	buf []byte
+}
+
+func (m *fakeLinearMemory) Grow(deltaPages int32) (int32, error) {
+	offset := int32(len(m.buf))
+	m.buf = append(m.buf, make([]byte, int(deltaPages)*wasmPageSize)...)
+	return offset, nil
+}
+
+func (m *fakeLinearMemory) Write(offset int32, data []byte) error {
+	if int(offset)+len(data) > len(m.buf) {
+		return errors.New("write out of bounds")
+	}
+	copy(m.buf[offset:], data)
+	return nil
+}
+
+func TestPlaceArgsPassesScalarsThrough(t *testing.T) {
+	mem := &fakeLinearMemory{}
+	out, err := PlaceArgs(mem, []interface{}{int32(7), int64(8)})
+	if err != nil {
+		t.Fatalf("PlaceArgs: %v", err)
+	}
+	if out[0] != int32(7) || out[1] != int64(8) {
+		t.Fatalf("scalars were not passed through unchanged: %#v", out)
+	}
+}
+
+func TestPlaceArgsWritesPreparedArgs(t *testing.T) {
+	mem := &fakeLinearMemory{}
+	blob := []byte{1, 2, 3, 4, 5}
+	out, err := PlaceArgs(mem, []interface{}{&PreparedArg{Blob: blob}})
+	if err != nil {
+		t.Fatalf("PlaceArgs: %v", err)
+	}
+	ptr, ok := out[0].(int32)
+	if !ok {
+		t.Fatalf("got %T, want int32 pointer", out[0])
+	}
+
+	length := int32(mem.buf[ptr])<<24 | int32(mem.buf[ptr+1])<<16 | int32(mem.buf[ptr+2])<<8 | int32(mem.buf[ptr+3])
+	if int(length) != len(blob) {
+		t.Fatalf("got length prefix %d, want %d", length, len(blob))
+	}
+	if !bytes.Equal(mem.buf[ptr+4:ptr+4+length], blob) {
+		t.Fatalf("written blob doesn't match: %v", mem.buf[ptr+4:ptr+4+length])
+	}
+}
@@ -0,0 +1,136 @@
+package wasmvm
+
+import (
+	encjson "encoding/json"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// pubSubUpgrader upgrades the "/ws" endpoint's HTTP connections to
+// websockets. Origin checking is left to whatever's in front of the
+// node (as with the rest of gecko's APIs), so it's accepted here.
+var pubSubUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// wsSubscribeMessage is the client->server message that opens a
+// subscription: a LogFilter to match going forward.
+type wsSubscribeMessage struct {
+	Filter LogFilter `json:"filter"`
+}
+
+// wsUnsubscribeMessage is the client->server message that ends a
+// previously-opened subscription.
+type wsUnsubscribeMessage struct {
+	SubscriptionID ids.ID `json:"subscriptionID"`
+}
+
+// wsServerMessage is every server->client message: either the ack for
+// a new subscription (SubscriptionID set, Log zero) or a streamed log
+// (Log set).
+type wsServerMessage struct {
+	SubscriptionID *ids.ID `json:"subscriptionID,omitempty"`
+	Log            *Log    `json:"log,omitempty"`
+}
+
+// pubSubHandler serves "/ws": each connection can open any number of
+// log subscriptions (one per "subscribe" message received) and will
+// get every matching log pushed to it as it's accepted, until it
+// sends "unsubscribe" or disconnects.
+type pubSubHandler struct {
+	vm *VM
+}
+
+func (h *pubSubHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := pubSubUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.vm.Ctx.Log.Debug("couldn't upgrade websocket connection: %s", err)
+		return
+	}
+	defer conn.Close()
+
+	// gorilla/websocket only supports one concurrent writer per
+	// connection, but both the subscribe ack below and every pump
+	// goroutine need to write to it. outbox/done fan all of that
+	// through the single writeLoop goroutine instead. outbox is never
+	// closed - a pump can still be mid-select on it after this
+	// connection starts shutting down, and sending on a closed channel
+	// panics - so closing is the only shutdown signal writeLoop itself
+	// listens for, and done is the only one everyone else does.
+	outbox := make(chan wsServerMessage, 16)
+	done := make(chan struct{})
+	closing := make(chan struct{})
+	go h.writeLoop(conn, outbox, done, closing)
+	defer close(closing)
+
+	var subs []ids.ID
+	defer func() {
+		for _, id := range subs {
+			h.vm.logIndex.Unsubscribe(id)
+		}
+	}()
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var sub wsSubscribeMessage
+		var unsub wsUnsubscribeMessage
+		switch {
+		case encjson.Unmarshal(raw, &unsub) == nil && !unsub.SubscriptionID.Equals(ids.Empty):
+			h.vm.logIndex.Unsubscribe(unsub.SubscriptionID)
+			continue
+		case encjson.Unmarshal(raw, &sub) == nil:
+			subID := newFilterID()
+			logs := h.vm.logIndex.Subscribe(subID, sub.Filter)
+			subs = append(subs, subID)
+			select {
+			case outbox <- wsServerMessage{SubscriptionID: &subID}:
+			case <-done:
+				return
+			}
+			go h.pump(logs, outbox, done)
+		default:
+			continue
+		}
+	}
+}
+
+// writeLoop is the only goroutine allowed to call conn.WriteJSON: it
+// drains outbox (the subscribe ack above, and every pump below) until
+// a write fails or closing fires (ServeHTTP shutting down), in either
+// case closing done so outbox's other senders stop blocking on it.
+func (h *pubSubHandler) writeLoop(conn *websocket.Conn, outbox <-chan wsServerMessage, done, closing chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case msg := <-outbox:
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-closing:
+			return
+		}
+	}
+}
+
+// pump forwards every log received on logs to outbox until the
+// channel is closed (by Unsubscribe) or the connection is shutting
+// down (done closed).
+func (h *pubSubHandler) pump(logs <-chan Log, outbox chan<- wsServerMessage, done <-chan struct{}) {
+	for log := range logs {
+		logCopy := log
+		select {
+		case outbox <- wsServerMessage{Log: &logCopy}:
+		case <-done:
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package wasmvm
 
 import (
+	"bytes"
 	encjson "encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/json"
+	"github.com/ava-labs/gecko/vms/wasmvm/abi"
 
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/engine/common"
@@ -29,7 +31,11 @@ func (vm *VM) CreateStaticHandlers() map[string]*common.HTTPHandler { return nil
 // See API documentation for more information
 func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
 	handler := vm.SnowmanVM.NewHandler("wasm", &Service{vm: vm})
-	return map[string]*common.HTTPHandler{"": handler}
+	ws := &common.HTTPHandler{LockOptions: common.NoLock, Handler: &pubSubHandler{vm: vm}}
+	return map[string]*common.HTTPHandler{
+		"":    handler,
+		"/ws": ws,
+	}
 }
 
 // Service is the API service
@@ -59,7 +65,13 @@ type ArgAPI struct {
 	Value interface{} `json:"value"`
 }
 
-// Return argument as its go type
+// Return argument as its go type. Scalar types (int32, int64) are
+// returned as-is, matching the WASM value types accepted directly at
+// the call boundary. Any other ABI type (e.g. "uint256", "bytes32",
+// "address", "uint64[]", "tuple") is packed via the abi package into a
+// *PreparedArg; the host runtime writes its Blob into the called
+// instance's linear memory and substitutes the resulting i32 pointer
+// immediately before the call (see PlaceArgs).
 func (arg *ArgAPI) toFnArg() (interface{}, error) {
 	switch strings.ToLower(arg.Type) {
 	case "int32":
@@ -91,7 +103,15 @@ func (arg *ArgAPI) toFnArg() (interface{}, error) {
 		}
 		return nil, fmt.Errorf("value '%v' is not convertible to int64", arg.Value)
 	default:
-		return nil, errors.New("arg type must be one of: int32, int64")
+		typ, err := abi.NewType(arg.Type)
+		if err != nil {
+			return nil, fmt.Errorf("arg type must be int32, int64, or a valid ABI type: %v", err)
+		}
+		packed, err := (abi.Arguments{{Type: typ}}).Pack(arg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't pack ABI arg: %v", err)
+		}
+		return &PreparedArg{Blob: packed}, nil
 	}
 }
 
@@ -101,6 +121,12 @@ type InvokeArgs struct {
 	ContractID ids.ID `json:"contractID"`
 	// Function in contract to invoke
 	Function string `json:"function"`
+	// Selector is the 4-byte function selector to invoke, as an
+	// alternative to specifying Function by name. Exactly one of
+	// Function or Selector must be given. Resolving a selector
+	// requires the contract to have been registered with an ABI
+	// (see CreateContractArgs.ABI).
+	Selector formatting.CB58 `json:"selector,omitempty"`
 	// Private Key signing the invocation tx
 	// This key's address is the "sender" of the tx
 	// Must be byte repr. of a SECP256K1R private key
@@ -121,8 +147,12 @@ func (args *InvokeArgs) validate() error {
 		return errors.New("'senderNonce' must be at least 1")
 	case args.ContractID.Equals(ids.Empty):
 		return errors.New("contractID not specified")
-	case args.Function == "":
-		return errors.New("function not specified")
+	case args.Function == "" && len(args.Selector.Bytes) == 0:
+		return errors.New("must specify exactly one of 'function' or 'selector'")
+	case args.Function != "" && len(args.Selector.Bytes) != 0:
+		return errors.New("must specify exactly one of 'function' or 'selector'")
+	case args.Function == "" && len(args.Selector.Bytes) != abi.SelectorLength:
+		return fmt.Errorf("'selector' must be %d bytes", abi.SelectorLength)
 	}
 	return nil
 }
@@ -153,6 +183,30 @@ func (args *InvokeArgs) getByteArgs() ([]byte, error) {
 	return formatter.Bytes, nil
 }
 
+// resolveFunction returns function unchanged if it's non-empty, or
+// else resolves selector against contractID's registered ABI. Exactly
+// one of function or selector is expected to be set; callers validate
+// that before calling this.
+func (s *Service) resolveFunction(contractID ids.ID, function string, selector []byte) (string, error) {
+	if function != "" {
+		return function, nil
+	}
+
+	contractABI, err := s.vm.getContractABI(s.vm.DB, contractID)
+	if err != nil {
+		return "", fmt.Errorf("couldn't resolve 'selector': contract has no registered ABI: %v", err)
+	}
+	parsedABI, err := abi.JSON(bytes.NewReader(contractABI))
+	if err != nil {
+		return "", fmt.Errorf("couldn't parse contract's ABI: %v", err)
+	}
+	method, err := parsedABI.MethodByID(selector)
+	if err != nil {
+		return "", fmt.Errorf("couldn't resolve 'selector': %v", err)
+	}
+	return method.Name, nil
+}
+
 // InvokeResponse ...
 type InvokeResponse struct {
 	TxID ids.ID `json:"txID"`
@@ -165,8 +219,12 @@ func (s *Service) Invoke(_ *http.Request, args *InvokeArgs, response *InvokeResp
 		return fmt.Errorf("arguments failed validation: %v", err)
 	}
 
+	function, err := s.resolveFunction(args.ContractID, args.Function, args.Selector.Bytes)
+	if err != nil {
+		return err
+	}
+
 	fnArgs := make([]interface{}, len(args.Args))
-	var err error
 	for i, arg := range args.Args {
 		fnArgs[i], err = arg.toFnArg()
 		if err != nil {
@@ -189,7 +247,7 @@ func (s *Service) Invoke(_ *http.Request, args *InvokeArgs, response *InvokeResp
 		return fmt.Errorf("couldn't parse 'privateKey' to a SECP256K1R private key: %v", err)
 	}
 
-	tx, err := s.vm.newInvokeTx(args.ContractID, args.Function, fnArgs, byteArgs, uint64(args.SenderNonce), senderKey)
+	tx, err := s.vm.newInvokeTx(args.ContractID, function, fnArgs, byteArgs, uint64(args.SenderNonce), senderKey)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %s", err)
 	}
@@ -214,6 +272,12 @@ type CreateContractArgs struct {
 
 	// Next unused nonce of the sender
 	SenderNonce json.Uint64 `json:"senderNonce"`
+
+	// ABI is the optional JSON ABI describing this contract's callable
+	// functions. If provided, it is stored alongside the contract and
+	// can later be retrieved with GetContractABI, and used to resolve
+	// Invoke calls specified by selector rather than function name.
+	ABI encjson.RawMessage `json:"abi,omitempty"`
 }
 
 // CreateContract creates a new contract
@@ -242,8 +306,18 @@ func (s *Service) CreateContract(_ *http.Request, args *CreateContractArgs, resp
 		return fmt.Errorf("couldn't parse 'senderKey' to a SECP256K1R private key: %v", err)
 	}
 
+	// Parse the optional ABI, if one was given, so obviously malformed
+	// ABIs are rejected before a tx is ever created.
+	var contractABI []byte
+	if len(args.ABI) > 0 {
+		if _, err := abi.JSON(bytes.NewReader(args.ABI)); err != nil {
+			return fmt.Errorf("couldn't parse 'abi': %v", err)
+		}
+		contractABI = []byte(args.ABI)
+	}
+
 	// Create tx
-	tx, err := s.vm.newCreateContractTx(args.Contract.Bytes, uint64(args.SenderNonce), senderKey)
+	tx, err := s.vm.newCreateContractTx(args.Contract.Bytes, contractABI, uint64(args.SenderNonce), senderKey)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %v", err)
 	}
@@ -265,6 +339,9 @@ type GetTxArgs struct {
 // GetTxResponse ...
 type GetTxResponse struct {
 	Tx *txReturnValue `json:"receipt"`
+	// Logs are the events txReturnValue's tx emitted via gecko_log
+	// while it ran, in emission order.
+	Logs []Log `json:"logs"`
 }
 
 // GetTx returns a tx by its ID
@@ -274,5 +351,303 @@ func (s *Service) GetTx(_ *http.Request, args *GetTxArgs, response *GetTxRespons
 		return fmt.Errorf("couldn't find tx with ID %s", args.ID)
 	}
 	response.Tx = tx
+	response.Logs = s.vm.logIndex.LogsForTx(args.ID)
+	return nil
+}
+
+// GetLogsArgs ...
+type GetLogsArgs struct {
+	// ContractIDs restricts results to logs from these contracts. If
+	// empty, logs from any contract are considered.
+	ContractIDs []ids.ID `json:"contractIDs,omitempty"`
+	// Topics matches per-position: Topics[i] is the set of values
+	// acceptable at topic position i (OR'd together), and an empty
+	// position matches any topic there. If Topics is shorter than a
+	// log's topic list, the remaining positions are unconstrained.
+	Topics [][]ids.ID `json:"topics,omitempty"`
+	// FromBlock is the first block height to consider.
+	FromBlock json.Uint64 `json:"fromBlock"`
+	// ToBlock is the last block height to consider, or 0 for no
+	// upper bound.
+	ToBlock json.Uint64 `json:"toBlock,omitempty"`
+}
+
+// GetLogsResponse ...
+type GetLogsResponse struct {
+	Logs []Log `json:"logs"`
+}
+
+// GetLogs returns every previously-accepted log matching the given
+// filter.
+func (s *Service) GetLogs(_ *http.Request, args *GetLogsArgs, response *GetLogsResponse) error {
+	logs := s.vm.logIndex.Query(LogFilter{
+		ContractIDs: args.ContractIDs,
+		Topics:      args.Topics,
+		FromBlock:   uint64(args.FromBlock),
+		ToBlock:     uint64(args.ToBlock),
+	})
+	response.Logs = logs
+	return nil
+}
+
+// NewFilterArgs ...
+type NewFilterArgs struct {
+	ContractIDs []ids.ID    `json:"contractIDs,omitempty"`
+	Topics      [][]ids.ID  `json:"topics,omitempty"`
+	FromBlock   json.Uint64 `json:"fromBlock"`
+	ToBlock     json.Uint64 `json:"toBlock,omitempty"`
+}
+
+// NewFilterResponse ...
+type NewFilterResponse struct {
+	FilterID ids.ID `json:"filterID"`
+}
+
+// NewFilter registers a server-side filter that accumulates logs
+// matching it as they're accepted. Call GetFilterChanges to drain
+// what it's collected so far.
+func (s *Service) NewFilter(_ *http.Request, args *NewFilterArgs, response *NewFilterResponse) error {
+	filterID := newFilterID()
+	s.vm.logIndex.NewFilter(filterID, LogFilter{
+		ContractIDs: args.ContractIDs,
+		Topics:      args.Topics,
+		FromBlock:   uint64(args.FromBlock),
+		ToBlock:     uint64(args.ToBlock),
+	})
+	response.FilterID = filterID
+	return nil
+}
+
+// GetFilterChangesArgs ...
+type GetFilterChangesArgs struct {
+	FilterID ids.ID `json:"filterID"`
+}
+
+// GetFilterChangesResponse ...
+type GetFilterChangesResponse struct {
+	Logs []Log `json:"logs"`
+}
+
+// GetFilterChanges returns, and clears, the logs a NewFilter
+// registration has matched since the last call.
+func (s *Service) GetFilterChanges(_ *http.Request, args *GetFilterChangesArgs, response *GetFilterChangesResponse) error {
+	logs, ok := s.vm.logIndex.FilterChanges(args.FilterID)
+	if !ok {
+		return fmt.Errorf("no such filter: %s", args.FilterID)
+	}
+	response.Logs = logs
+	return nil
+}
+
+// UninstallFilterArgs ...
+type UninstallFilterArgs struct {
+	FilterID ids.ID `json:"filterID"`
+}
+
+// UninstallFilterResponse ...
+type UninstallFilterResponse struct {
+	Success bool `json:"success"`
+}
+
+// UninstallFilter removes a filter previously registered with
+// NewFilter.
+func (s *Service) UninstallFilter(_ *http.Request, args *UninstallFilterArgs, response *UninstallFilterResponse) error {
+	response.Success = s.vm.logIndex.Unsubscribe(args.FilterID)
+	return nil
+}
+
+// GetContractABIArgs ...
+type GetContractABIArgs struct {
+	ContractID ids.ID `json:"contractID"`
+}
+
+// GetContractABIResponse ...
+type GetContractABIResponse struct {
+	// ABI is the exact JSON the contract was registered with at
+	// CreateContract time (the "abi" field of CreateContractArgs),
+	// returned verbatim rather than re-encoded, so it round-trips
+	// byte-for-byte.
+	ABI encjson.RawMessage `json:"abi"`
+}
+
+// GetContractABI returns the ABI a contract was registered with at
+// CreateContract time. It returns an error if the contract doesn't
+// exist or was created without an ABI.
+func (s *Service) GetContractABI(_ *http.Request, args *GetContractABIArgs, response *GetContractABIResponse) error {
+	if args.ContractID.Equals(ids.Empty) {
+		return errors.New("contractID not specified")
+	}
+
+	contractABI, err := s.vm.getContractABI(s.vm.DB, args.ContractID)
+	if err != nil {
+		return fmt.Errorf("couldn't find ABI for contract %s: %v", args.ContractID, err)
+	}
+
+	// Sanity-check it's still parseable, but return the stored bytes
+	// themselves rather than a struct re-encoded from them.
+	if _, err := abi.JSON(bytes.NewReader(contractABI)); err != nil {
+		return fmt.Errorf("couldn't parse stored ABI: %v", err)
+	}
+	response.ABI = encjson.RawMessage(contractABI)
+	return nil
+}
+
+// CallArgs ...
+type CallArgs struct {
+	// Contract to call
+	ContractID ids.ID `json:"contractID"`
+	// Function in contract to call
+	Function string `json:"function"`
+	// Selector is an alternative to Function; see InvokeArgs.Selector.
+	Selector formatting.CB58 `json:"selector,omitempty"`
+	// Typed arguments to the function: int32/int64 scalars or any ABI
+	// type (see ArgAPI.toFnArg)
+	Args []ArgAPI `json:"args"`
+	// Byte arguments to the function
+	ByteArgs interface{} `json:"byteArgs"`
+	// GasBudget caps how much gas the call may consume before it's
+	// aborted with ErrOutOfGas. Defaults to DefaultGasBudget.
+	GasBudget json.Uint64 `json:"gasBudget,omitempty"`
+	// StateOverrides lets the call run against contract storage and
+	// balances temporarily mutated for this simulation only.
+	StateOverrides *StateOverride `json:"stateOverrides,omitempty"`
+}
+
+func (args *CallArgs) gasBudget() uint64 {
+	if args.GasBudget == 0 {
+		return DefaultGasBudget
+	}
+	return uint64(args.GasBudget)
+}
+
+func (args *CallArgs) stateOverride() StateOverride {
+	if args.StateOverrides == nil {
+		return StateOverride{}
+	}
+	return *args.StateOverrides
+}
+
+// CallResponse ...
+type CallResponse struct {
+	// ReturnValue is the call's packed return value.
+	ReturnValue formatting.CB58 `json:"returnValue"`
+	// GasUsed is how much gas the call consumed.
+	GasUsed json.Uint64 `json:"gasUsed"`
+}
+
+// Call executes an invocation against the current accepted state
+// without submitting a tx ("eth_call" for wasmvm): no mempool entry,
+// no block, nothing persisted. It's metered the same way a real
+// invocation would be, so the caller also learns the gas it would
+// cost.
+func (s *Service) Call(_ *http.Request, args *CallArgs, response *CallResponse) error {
+	if args.ContractID.Equals(ids.Empty) {
+		return errors.New("contractID not specified")
+	}
+
+	function, err := s.resolveFunction(args.ContractID, args.Function, args.Selector.Bytes)
+	if err != nil {
+		return err
+	}
+
+	fnArgs := make([]interface{}, len(args.Args))
+	for i, arg := range args.Args {
+		fnArgs[i], err = arg.toFnArg()
+		if err != nil {
+			return fmt.Errorf("couldn't parse arg '%+v': %s", arg, err)
+		}
+	}
+	byteArgs, err := (&InvokeArgs{ByteArgs: args.ByteArgs}).getByteArgs()
+	if err != nil {
+		return fmt.Errorf("couldn't parse 'byteArgs': %v", err)
+	}
+
+	state := NewStateView(s.vm.DB, args.stateOverride())
+	meter := NewGasMeter(args.gasBudget(), DefaultGasCostTable)
+
+	ret, err := s.vm.InvokeMetered(state, args.ContractID, function, fnArgs, byteArgs, meter)
+	if err != nil {
+		return fmt.Errorf("call failed (gas used %d): %v", meter.Used(), err)
+	}
+
+	response.ReturnValue = formatting.CB58{Bytes: ret}
+	response.GasUsed = json.Uint64(meter.Used())
+	return nil
+}
+
+// EstimateGasArgs ...
+type EstimateGasArgs struct {
+	ContractID     ids.ID          `json:"contractID"`
+	Function       string          `json:"function"`
+	Selector       formatting.CB58 `json:"selector,omitempty"`
+	Args           []ArgAPI        `json:"args"`
+	ByteArgs       interface{}     `json:"byteArgs"`
+	StateOverrides *StateOverride  `json:"stateOverrides,omitempty"`
+}
+
+func (args *EstimateGasArgs) stateOverride() StateOverride {
+	if args.StateOverrides == nil {
+		return StateOverride{}
+	}
+	return *args.StateOverrides
+}
+
+// EstimateGasResponse ...
+type EstimateGasResponse struct {
+	// GasEstimate is the minimum gas budget under which the call
+	// succeeds.
+	GasEstimate json.Uint64 `json:"gasEstimate"`
+}
+
+// EstimateGas binary-searches the minimum gas budget under which a
+// call to function succeeds, mirroring how the EVM client in coreth
+// exposes EstimateGas.
+func (s *Service) EstimateGas(_ *http.Request, args *EstimateGasArgs, response *EstimateGasResponse) error {
+	if args.ContractID.Equals(ids.Empty) {
+		return errors.New("contractID not specified")
+	}
+
+	function, err := s.resolveFunction(args.ContractID, args.Function, args.Selector.Bytes)
+	if err != nil {
+		return err
+	}
+
+	fnArgs := make([]interface{}, len(args.Args))
+	for i, arg := range args.Args {
+		fnArgs[i], err = arg.toFnArg()
+		if err != nil {
+			return fmt.Errorf("couldn't parse arg '%+v': %s", arg, err)
+		}
+	}
+	byteArgs, err := (&InvokeArgs{ByteArgs: args.ByteArgs}).getByteArgs()
+	if err != nil {
+		return fmt.Errorf("couldn't parse 'byteArgs': %v", err)
+	}
+
+	state := NewStateView(s.vm.DB, args.stateOverride())
+
+	// First check the call can succeed at all within a generous
+	// ceiling; otherwise the search below would just converge on that
+	// ceiling and report a budget that doesn't actually work.
+	probe := NewGasMeter(maxGasEstimateSearch, DefaultGasCostTable)
+	if _, err := s.vm.InvokeMetered(state, args.ContractID, function, fnArgs, byteArgs, probe); err != nil {
+		return fmt.Errorf("call fails even with a %d gas budget: %v", maxGasEstimateSearch, err)
+	}
+
+	lo, hi := uint64(1), uint64(maxGasEstimateSearch)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		meter := NewGasMeter(mid, DefaultGasCostTable)
+		if _, err := s.vm.InvokeMetered(state, args.ContractID, function, fnArgs, byteArgs, meter); err != nil {
+			if !errors.Is(err, ErrOutOfGas) {
+				return fmt.Errorf("call failed: %v", err)
+			}
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	response.GasEstimate = json.Uint64(lo)
 	return nil
 }
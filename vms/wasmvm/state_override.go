@@ -0,0 +1,56 @@
+package wasmvm
+
+import "github.com/ava-labs/gecko/database"
+
+// StateOverride lets a Call or EstimateGas simulation temporarily
+// mutate what a contract's execution observes, without ever touching
+// accepted state: specific storage keys can be pinned to a given
+// value, and specific addresses can be given an overridden balance.
+type StateOverride struct {
+	// Storage overrides raw storage keys, in whatever key scheme the
+	// interpreter's storage layer already addresses a contract's
+	// key-value pairs with.
+	Storage map[string][]byte `json:"storage,omitempty"`
+	// Balances overrides an address's balance, keyed by its CB58
+	// string representation.
+	Balances map[string]uint64 `json:"balances,omitempty"`
+}
+
+// StateView is a read-only view of accepted state with a
+// StateOverride layered on top. It's what the metered interpreter
+// reads from during a Call/EstimateGas simulation; nothing written
+// through a StateView is ever persisted.
+type StateView struct {
+	db       database.Database
+	override StateOverride
+}
+
+// NewStateView returns a StateView reading db, with override applied
+// on top.
+func NewStateView(db database.Database, override StateOverride) *StateView {
+	return &StateView{db: db, override: override}
+}
+
+// Get returns key's value, preferring an override if one was
+// supplied for it.
+func (v *StateView) Get(key []byte) ([]byte, error) {
+	if val, ok := v.override.Storage[string(key)]; ok {
+		return val, nil
+	}
+	return v.db.Get(key)
+}
+
+// Has reports whether key has a value, preferring an override if one
+// was supplied for it.
+func (v *StateView) Has(key []byte) (bool, error) {
+	if _, ok := v.override.Storage[string(key)]; ok {
+		return true, nil
+	}
+	return v.db.Has(key)
+}
+
+// BalanceOverride returns the overridden balance for address, if any.
+func (v *StateView) BalanceOverride(address string) (uint64, bool) {
+	bal, ok := v.override.Balances[address]
+	return bal, ok
+}